@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const apksTocPath = "toc.pb"
+
+// updateApks applies the manifest edits to every APK within an APK set (.apks) generated by bundletool and keeps the
+// table of contents in sync.
+func updateApks(a *archive, config *Config) error {
+	if len(config.bundleConfigEdits) > 0 {
+		return errBundleConfigEdits
+	}
+	if config.networkSecurityConfigXml != nil {
+		return errNetworkSecurityConfigInjection
+	}
+
+	var apks []string
+	for _, f := range a.reader.File {
+		if strings.HasSuffix(f.Name, ".apk") {
+			apks = append(apks, f.Name)
+		}
+	}
+	toc, err := readApksToc(a)
+	if err != nil {
+		return err
+	}
+	tocApks := readTocApks(toc)
+
+	packages := map[string][]string{}
+	versionCodes := map[int32][]string{}
+	// Split APKs are installed together and must have the same versionCode, so the versionCode offsets only apply to
+	// standalone APKs.
+	baseConfig := *config
+	baseConfig.versionCodeOffsets = nil
+	splitConfig := splitApkConfig(&baseConfig)
+	for _, apk := range apks {
+		tocApk, ok := tocApks[apk]
+		if !ok {
+			return fmt.Errorf("%s is missing in %s", apk, apksTocPath)
+		}
+		config.println("Updating", apk)
+		apkConfig := splitConfig
+		switch {
+		case tocApk.standalone && config.versionCodeOffsets != nil:
+			standaloneConfig := baseConfig
+			if standaloneConfig.versionCode, err = offsetVersionCode(tocApk.abi, tocApk.density, config); err != nil {
+				return fmt.Errorf("%s: %w", apk, err)
+			}
+			apkConfig = &standaloneConfig
+		case tocApk.standalone:
+			apkConfig = config
+		case tocApk.master && tocApk.module == baseModuleName:
+			apkConfig = &baseConfig
+		}
+		var manifest *XmlNode
+		err := a.update(apk, func(data []byte) ([]byte, error) {
+			var out []byte
+			out, manifest, err = updateApk(data, apkConfig)
+			return out, err
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", apk, err)
+		}
+		packageName := getManifestAttribute(manifest, "", "package").GetValue()
+		packages[packageName] = append(packages[packageName], apk)
+		if !tocApk.standalone || config.versionCodeOffsets == nil {
+			versionCode := getVersionCode(manifest)
+			versionCodes[versionCode] = append(versionCodes[versionCode], apk)
+		}
+	}
+	if len(packages) > 1 {
+		return fmt.Errorf("the APKs have different packages: %v", packages)
+	}
+	if len(versionCodes) > 1 {
+		return fmt.Errorf("the APKs have different versionCodes: %v", versionCodes)
+	}
+
+	if config.packageName != "" {
+		return a.update(apksTocPath, func(data []byte) ([]byte, error) {
+			return updateApksToc(data, config)
+		})
+	}
+	return nil
+}
+
+// splitApkConfig returns the part of the config which applies to the split APKs other than the base module's master
+// split. Feature modules, asset slices and config splits don't declare the app's components and have their own
+// resources, so only the package and versionCode have to match the other APKs.
+func splitApkConfig(config *Config) *Config {
+	return &Config{
+		versionCode:    config.versionCode,
+		packageName:    config.packageName,
+		keepLocales:    config.keepLocales,
+		keepDensities:  config.keepDensities,
+		skipValidation: config.skipValidation,
+		signing:        config.signing,
+		linker:         config.linker,
+		out:            config.out,
+	}
+}
+
+// baseModuleName is the name of the base module in AABs and APK sets.
+const baseModuleName = "base"
+
+// tocApk describes an APK of an APK set as listed in the toc.pb.
+type tocApk struct {
+	module string
+	// standalone is set for the APKs of devices without split APK support, which contain all modules.
+	standalone bool
+	// master is set for the master split of a module, as opposed to its config splits.
+	master  bool
+	abi     string
+	density string
+}
+
+// readTocApks returns the APKs of the table of contents by path.
+func readTocApks(toc *BuildApksResult) map[string]tocApk {
+	apks := map[string]tocApk{}
+	add := func(module string, descriptions []*ApkDescription) {
+		for _, description := range descriptions {
+			apk := tocApk{
+				module:     module,
+				standalone: description.GetStandaloneApkMetadata() != nil,
+				master:     description.GetSplitApkMetadata().GetIsMasterSplit(),
+			}
+			apk.abi, apk.density = apkTargeting(description.GetTargeting())
+			apks[description.GetPath()] = apk
+		}
+	}
+	for _, variant := range toc.GetVariant() {
+		for _, apkSet := range variant.GetApkSet() {
+			add(apkSet.GetModuleMetadata().GetName(), apkSet.GetApkDescription())
+		}
+	}
+	for _, sliceSet := range toc.GetAssetSliceSet() {
+		add(sliceSet.GetAssetModuleMetadata().GetName(), sliceSet.GetApkDescription())
+	}
+	return apks
+}
+
+// readApksToc reads the APK set's table of contents.
+func readApksToc(a *archive) (*BuildApksResult, error) {
+	if a.file(apksTocPath) == nil {
+		return nil, fmt.Errorf("not an APK set: %s is missing", apksTocPath)
+	}
+	data, err := a.read(apksTocPath)
+	if err != nil {
+		return nil, err
+	}
+	toc := &BuildApksResult{}
+	if err := toc.UnmarshalVT(data); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", apksTocPath, err)
+	}
+	return toc, nil
+}
+
+func updateApksToc(in []byte, config *Config) ([]byte, error) {
+	toc := &BuildApksResult{}
+	if err := toc.UnmarshalVT(in); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", apksTocPath, err)
+	}
+	if config.packageName != "" && toc.PackageName != config.packageName {
+		config.println("Changing", apksTocPath, "packageName from", toc.PackageName, "to", config.packageName)
+		toc.PackageName = config.packageName
+	}
+
+	out, err := toc.MarshalVT()
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling %s: %w", apksTocPath, err)
+	}
+	return out, nil
+}
+
+// getManifestAttribute returns the given attribute of the root <manifest> element or nil if it doesn't exist.
+func getManifestAttribute(manifest *XmlNode, namespaceUri string, name string) *XmlAttribute {
+	return findAttribute(manifest.GetElement(), namespaceUri, name)
+}
+
+func getVersionCode(manifest *XmlNode) int32 {
+	attr := getManifestAttribute(manifest, namespace, versionCodeAttr)
+	if x, ok := attr.GetCompiledItem().GetPrim().GetOneofValue().(*Primitive_IntDecimalValue); ok {
+		return x.IntDecimalValue
+	}
+	versionCode, err := strconv.ParseInt(attr.GetValue(), 0, 32)
+	if err != nil {
+		return 0
+	}
+	return int32(versionCode)
+}
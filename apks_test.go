@@ -0,0 +1,54 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReadTocApks(t *testing.T) {
+	split := func(path string, master bool, targeting *ApkTargeting) *ApkDescription {
+		return &ApkDescription{Path: path, Targeting: targeting, ApkMetadataOneofValue: &ApkDescription_SplitApkMetadata{
+			SplitApkMetadata: &SplitApkMetadata{IsMasterSplit: master},
+		}}
+	}
+	abiTargeting := &ApkTargeting{AbiTargeting: &AbiTargeting{Value: []*Abi{{Alias: Abi_ARM64_V8A}}}}
+	toc := &BuildApksResult{
+		Variant: []*Variant{
+			{ApkSet: []*ApkSet{{
+				ModuleMetadata: &ModuleMetadata{Name: "base"},
+				ApkDescription: []*ApkDescription{{
+					Path:                  "standalones/standalone-arm64_v8a.apk",
+					Targeting:             abiTargeting,
+					ApkMetadataOneofValue: &ApkDescription_StandaloneApkMetadata{StandaloneApkMetadata: &StandaloneApkMetadata{}},
+				}},
+			}}},
+			{ApkSet: []*ApkSet{
+				{
+					ModuleMetadata: &ModuleMetadata{Name: "base"},
+					ApkDescription: []*ApkDescription{
+						split("splits/base-master.apk", true, nil),
+						split("splits/base-arm64_v8a.apk", false, abiTargeting),
+					},
+				},
+				{
+					ModuleMetadata: &ModuleMetadata{Name: "feature"},
+					ApkDescription: []*ApkDescription{split("splits/feature-master.apk", true, nil)},
+				},
+			}},
+		},
+		AssetSliceSet: []*AssetSliceSet{{
+			AssetModuleMetadata: &AssetModuleMetadata{Name: "assets"},
+			ApkDescription:      []*ApkDescription{split("asset-slices/assets-master.apk", true, nil)},
+		}},
+	}
+	want := map[string]tocApk{
+		"standalones/standalone-arm64_v8a.apk": {module: "base", standalone: true, abi: "arm64-v8a"},
+		"splits/base-master.apk":               {module: "base", master: true},
+		"splits/base-arm64_v8a.apk":            {module: "base", abi: "arm64-v8a"},
+		"splits/feature-master.apk":            {module: "feature", master: true},
+		"asset-slices/assets-master.apk":       {module: "assets", master: true},
+	}
+	if got := readTocApks(toc); !reflect.DeepEqual(got, want) {
+		t.Errorf("readTocApks() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"sort"
+)
+
+// archive is a zip file (AAB, APK, AAR, ...) whose entries can be replaced or removed. Unchanged entries are copied
+// as-is without recompressing them.
+type archive struct {
+	reader   *zip.Reader
+	updates  map[string][]byte
+	removals map[string]bool
+	// additions are the names of new entries (in order), whose contents are in updates.
+	additions []string
+}
+
+func openArchive(r io.ReaderAt, size int64) (*archive, error) {
+	reader, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	return &archive{reader: reader, updates: map[string][]byte{}, removals: map[string]bool{}}, nil
+}
+
+// file returns the entry with the given name or nil if it doesn't exist.
+func (a *archive) file(name string) *zip.File {
+	if a.removals[name] {
+		return nil
+	}
+	return findFile(a.reader, name)
+}
+
+// read returns the (possibly already updated) contents of the given entry.
+func (a *archive) read(name string) ([]byte, error) {
+	if data, ok := a.updates[name]; ok {
+		return data, nil
+	}
+	f := a.file(name)
+	if f == nil {
+		return nil, fmt.Errorf("%s is missing", name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed opening zip file's %s: %w", name, err)
+	}
+	defer rc.Close()
+	return ioutil.ReadAll(rc)
+}
+
+// update replaces the contents of the given entry with the result of the update function.
+func (a *archive) update(name string, update func(data []byte) ([]byte, error)) error {
+	data, err := a.read(name)
+	if err != nil {
+		return err
+	}
+	if data, err = update(data); err != nil {
+		return err
+	}
+	a.updates[name] = data
+	return nil
+}
+
+// add creates a new entry or replaces the contents of an existing one.
+func (a *archive) add(name string, data []byte) {
+	if a.file(name) == nil {
+		if _, added := a.updates[name]; !added {
+			a.additions = append(a.additions, name)
+		}
+	}
+	a.updates[name] = data
+}
+
+func (a *archive) remove(names ...string) {
+	for _, name := range names {
+		a.removals[name] = true
+	}
+}
+
+// writeTo writes the modified zip. The updated entries keep their compression method. Unlike zip.Writer.Create, the
+// entries are written without data descriptors because some tools reading AABs and APKs can't handle them.
+func (a *archive) writeTo(w io.Writer) error {
+	zw := zip.NewWriter(w)
+	if err := zw.SetComment(a.reader.Comment); err != nil {
+		return err
+	}
+	for _, f := range a.reader.File {
+		if a.removals[f.Name] {
+			continue
+		}
+		data, updated := a.updates[f.Name]
+		if !updated {
+			if err := zw.Copy(f); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := writeRawEntry(zw, f.FileHeader, data); err != nil {
+			return err
+		}
+	}
+	for _, name := range a.additions {
+		header := zip.FileHeader{Name: name, Method: zip.Deflate}
+		header.SetMode(0644)
+		if err := writeRawEntry(zw, header, a.updates[name]); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// writeRawEntry compresses the data itself, so the sizes and the CRC are known before writing the entry.
+func writeRawEntry(zw *zip.Writer, header zip.FileHeader, data []byte) error {
+	header.Flags &^= 0x8
+	header.CRC32 = crc32.ChecksumIEEE(data)
+	header.UncompressedSize64 = uint64(len(data))
+	compressed := data
+	if header.Method == zip.Deflate {
+		var err error
+		if compressed, err = deflate(data); err != nil {
+			return err
+		}
+	} else {
+		header.Method = zip.Store
+	}
+	header.CompressedSize64 = uint64(len(compressed))
+	fw, err := zw.CreateRaw(&header)
+	if err != nil {
+		return err
+	}
+	_, err = fw.Write(compressed)
+	return err
+}
+
+// names returns the names of all entries in sorted order.
+func (a *archive) names() []string {
+	var names []string
+	for _, f := range a.reader.File {
+		if !a.removals[f.Name] {
+			names = append(names, f.Name)
+		}
+	}
+	names = append(names, a.additions...)
+	sort.Strings(names)
+	return names
+}
+
+func deflate(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"strings"
+)
+
+// Chunk types and flags of the binary resource table (resources.arsc, see ResourceTypes.h).
+const (
+	resTableType        = 0x0002
+	resTablePackageType = 0x0200
+	resTableTypeType    = 0x0201
+
+	typeFlagSparse   = 0x01
+	typeFlagOffset16 = 0x02
+
+	entryFlagComplex = 0x0001
+	entryFlagCompact = 0x0008
+
+	// Keys of the map entries which describe an attribute.
+	attrTypeKey = 0x01000000
+	attrMinKey  = 0x01000003
+)
+
+var errInvalidResourceTable = errors.New("invalid resource table")
+
+// loadAndroidJar reads the framework resources from the resources.arsc of an android.jar from the Android SDK.
+func (l *resourceLinker) loadAndroidJar(path string) error {
+	data, err := readFromZip(path, "resources.arsc")
+	if err != nil {
+		return err
+	}
+	return l.loadBinaryResourceTable(data)
+}
+
+// loadBinaryResourceTable records the names and IDs of all resources and the definitions of all attributes.
+func (l *resourceLinker) loadBinaryResourceTable(data []byte) error {
+	if len(data) < 12 || binary.LittleEndian.Uint16(data) != resTableType {
+		return errInvalidResourceTable
+	}
+	// Attribute symbols reference android:id resources, which may come later in the table.
+	type pendingSymbol struct {
+		attr  *attrDef
+		keyId uint32
+		value uint32
+	}
+	var symbols []pendingSymbol
+	seen := map[uint32]bool{}
+
+	headerSize := int(binary.LittleEndian.Uint16(data[2:]))
+	for _, pkg := range resChunks(data, headerSize) {
+		if binary.LittleEndian.Uint16(pkg) != resTablePackageType || len(pkg) < 284 {
+			continue
+		}
+		pkgId := binary.LittleEndian.Uint32(pkg[8:])
+		pkgName := decodeFixedUtf16(pkg[12:268])
+		typeStringsOffset := binary.LittleEndian.Uint32(pkg[268:])
+		keyStringsOffset := binary.LittleEndian.Uint32(pkg[276:])
+		if int(typeStringsOffset) >= len(pkg) || int(keyStringsOffset) >= len(pkg) {
+			return errInvalidResourceTable
+		}
+		typeStrings, err := parseStringPool(pkg[typeStringsOffset:])
+		if err != nil {
+			return err
+		}
+		keyStrings, err := parseStringPool(pkg[keyStringsOffset:])
+		if err != nil {
+			return err
+		}
+
+		for _, chunk := range resChunks(pkg, int(binary.LittleEndian.Uint16(pkg[2:]))) {
+			if binary.LittleEndian.Uint16(chunk) != resTableTypeType || len(chunk) < 20 {
+				continue
+			}
+			typeId := uint32(chunk[8])
+			if typeId == 0 || int(typeId) > len(typeStrings) {
+				return errInvalidResourceTable
+			}
+			typeName := typeStrings[typeId-1]
+			entries, err := typeEntries(chunk)
+			if err != nil {
+				return err
+			}
+			for index, entry := range entries {
+				if entry == nil {
+					continue
+				}
+				if len(entry) < 8 {
+					return errInvalidResourceTable
+				}
+				flags := binary.LittleEndian.Uint16(entry[2:])
+				key := binary.LittleEndian.Uint32(entry[4:])
+				if flags&entryFlagCompact != 0 {
+					key = uint32(binary.LittleEndian.Uint16(entry))
+				}
+				if int(key) >= len(keyStrings) {
+					return errInvalidResourceTable
+				}
+				id := pkgId<<24 | typeId<<16 | uint32(index)
+				name := pkgName + ":" + typeName + "/" + keyStrings[key]
+				if seen[id] {
+					// Only the first configuration is needed.
+					continue
+				}
+				seen[id] = true
+				l.ids[name] = id
+				l.names[id] = name
+
+				if typeName != "attr" || pkgName != "android" || flags&entryFlagComplex == 0 || len(entry) < 16 {
+					continue
+				}
+				attr := &attrDef{id: id}
+				count := int(binary.LittleEndian.Uint32(entry[12:]))
+				maps := entry[binary.LittleEndian.Uint16(entry):]
+				if len(maps) < 12*count {
+					return errInvalidResourceTable
+				}
+				for i := 0; i < count; i++ {
+					keyId := binary.LittleEndian.Uint32(maps[12*i:])
+					value := binary.LittleEndian.Uint32(maps[12*i+8:])
+					if keyId == attrTypeKey {
+						attr.format = value
+					} else if keyId > attrMinKey {
+						symbols = append(symbols, pendingSymbol{attr, keyId, value})
+					}
+				}
+				l.attrs[keyStrings[key]] = attr
+			}
+		}
+	}
+
+	for _, symbol := range symbols {
+		name := l.names[symbol.keyId]
+		if i := strings.Index(name, "/"); i >= 0 {
+			if symbol.attr.symbols == nil {
+				symbol.attr.symbols = map[string]uint32{}
+			}
+			symbol.attr.symbols[name[i+1:]] = symbol.value
+		}
+	}
+	return nil
+}
+
+// resChunks splits the chunks following a header of the given size.
+func resChunks(data []byte, offset int) [][]byte {
+	var chunks [][]byte
+	for offset+8 <= len(data) {
+		size := int(binary.LittleEndian.Uint32(data[offset+4:]))
+		if size < 8 || offset+size > len(data) {
+			break
+		}
+		chunks = append(chunks, data[offset:offset+size])
+		offset += size
+	}
+	return chunks
+}
+
+// typeEntries returns the entries of a type chunk by their entry index. Missing entries are nil.
+func typeEntries(chunk []byte) ([][]byte, error) {
+	headerSize := int(binary.LittleEndian.Uint16(chunk[2:]))
+	flags := chunk[9]
+	count := int(binary.LittleEndian.Uint32(chunk[12:]))
+	entriesStart := int(binary.LittleEndian.Uint32(chunk[16:]))
+	entry := func(offset int) ([]byte, error) {
+		if entriesStart+offset >= len(chunk) {
+			return nil, errInvalidResourceTable
+		}
+		return chunk[entriesStart+offset:], nil
+	}
+
+	var entries [][]byte
+	offsets := chunk[headerSize:]
+	for i := 0; i < count; i++ {
+		switch {
+		case flags&typeFlagSparse != 0:
+			if len(offsets) < 4*(i+1) {
+				return nil, errInvalidResourceTable
+			}
+			index := int(binary.LittleEndian.Uint16(offsets[4*i:]))
+			e, err := entry(4 * int(binary.LittleEndian.Uint16(offsets[4*i+2:])))
+			if err != nil {
+				return nil, err
+			}
+			for len(entries) < index {
+				entries = append(entries, nil)
+			}
+			entries = append(entries, e)
+		case flags&typeFlagOffset16 != 0:
+			if len(offsets) < 2*(i+1) {
+				return nil, errInvalidResourceTable
+			}
+			offset := binary.LittleEndian.Uint16(offsets[2*i:])
+			if offset == 0xffff {
+				entries = append(entries, nil)
+				continue
+			}
+			e, err := entry(4 * int(offset))
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, e)
+		default:
+			if len(offsets) < 4*(i+1) {
+				return nil, errInvalidResourceTable
+			}
+			offset := binary.LittleEndian.Uint32(offsets[4*i:])
+			if offset == noIndex {
+				entries = append(entries, nil)
+				continue
+			}
+			e, err := entry(int(offset))
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// decodeFixedUtf16 decodes a zero-terminated UTF-16 string of a fixed size buffer like the package name.
+func decodeFixedUtf16(data []byte) string {
+	var name []rune
+	for i := 0; i+1 < len(data); i += 2 {
+		c := binary.LittleEndian.Uint16(data[i:])
+		if c == 0 {
+			break
+		}
+		name = append(name, rune(c))
+	}
+	return string(name)
+}
@@ -0,0 +1,244 @@
+package main
+
+// Format flags of an attribute definition (ResTable_map::ATTR_TYPE).
+const (
+	attrFormatReference = 1 << 0
+	attrFormatString    = 1 << 1
+	attrFormatInteger   = 1 << 2
+	attrFormatBoolean   = 1 << 3
+	attrFormatColor     = 1 << 4
+	attrFormatFloat     = 1 << 5
+	attrFormatDimension = 1 << 6
+	attrFormatFraction  = 1 << 7
+	attrFormatEnum      = 1 << 16
+	attrFormatFlags     = 1 << 17
+)
+
+// attrDef describes an attribute like android:versionCode: its resource ID, the allowed formats and the symbols of
+// enum and flag attributes.
+type attrDef struct {
+	id      uint32
+	format  uint32
+	symbols map[string]uint32
+}
+
+const (
+	booleanAttr = attrFormatBoolean
+	stringAttr  = attrFormatString
+	integerAttr = attrFormatInteger
+	refAttr     = attrFormatReference
+)
+
+// frameworkAttrs contains the android: attributes used in manifests. It is used if no android.jar is given.
+var frameworkAttrs = map[string]*attrDef{
+	"theme":                           {0x01010000, refAttr, nil},
+	"label":                           {0x01010001, refAttr | stringAttr, nil},
+	"icon":                            {0x01010002, refAttr, nil},
+	"name":                            {0x01010003, stringAttr, nil},
+	"manageSpaceActivity":             {0x01010004, stringAttr, nil},
+	"allowClearUserData":              {0x01010005, booleanAttr, nil},
+	"permission":                      {0x01010006, stringAttr, nil},
+	"readPermission":                  {0x01010007, stringAttr, nil},
+	"writePermission":                 {0x01010008, stringAttr, nil},
+	"protectionLevel":                 {0x01010009, attrFormatFlags, protectionLevels},
+	"permissionGroup":                 {0x0101000a, stringAttr, nil},
+	"sharedUserId":                    {0x0101000b, stringAttr, nil},
+	"hasCode":                         {0x0101000c, booleanAttr, nil},
+	"persistent":                      {0x0101000d, booleanAttr, nil},
+	"enabled":                         {0x0101000e, booleanAttr, nil},
+	"debuggable":                      {0x0101000f, booleanAttr, nil},
+	"exported":                        {0x01010010, booleanAttr, nil},
+	"process":                         {0x01010011, stringAttr, nil},
+	"taskAffinity":                    {0x01010012, stringAttr, nil},
+	"multiprocess":                    {0x01010013, booleanAttr, nil},
+	"finishOnTaskLaunch":              {0x01010014, booleanAttr, nil},
+	"clearTaskOnLaunch":               {0x01010015, booleanAttr, nil},
+	"stateNotNeeded":                  {0x01010016, booleanAttr, nil},
+	"excludeFromRecents":              {0x01010017, booleanAttr, nil},
+	"authorities":                     {0x01010018, stringAttr, nil},
+	"syncable":                        {0x01010019, booleanAttr, nil},
+	"initOrder":                       {0x0101001a, integerAttr, nil},
+	"grantUriPermissions":             {0x0101001b, booleanAttr, nil},
+	"priority":                        {0x0101001c, integerAttr, nil},
+	"launchMode":                      {0x0101001d, attrFormatEnum, launchModes},
+	"screenOrientation":               {0x0101001e, attrFormatEnum, screenOrientations},
+	"configChanges":                   {0x0101001f, attrFormatFlags, configChanges},
+	"description":                     {0x01010020, refAttr | stringAttr, nil},
+	"targetPackage":                   {0x01010021, stringAttr, nil},
+	"handleProfiling":                 {0x01010022, booleanAttr, nil},
+	"functionalTest":                  {0x01010023, booleanAttr, nil},
+	"value":                           {0x01010024, stringAttr | integerAttr | booleanAttr | attrFormatColor | attrFormatFloat, nil},
+	"resource":                        {0x01010025, refAttr, nil},
+	"mimeType":                        {0x01010026, stringAttr, nil},
+	"scheme":                          {0x01010027, stringAttr, nil},
+	"host":                            {0x01010028, stringAttr, nil},
+	"port":                            {0x01010029, stringAttr, nil},
+	"path":                            {0x0101002a, stringAttr, nil},
+	"pathPrefix":                      {0x0101002b, stringAttr, nil},
+	"pathPattern":                     {0x0101002c, stringAttr, nil},
+	"action":                          {0x0101002d, stringAttr, nil},
+	"data":                            {0x0101002e, stringAttr, nil},
+	"targetClass":                     {0x0101002f, stringAttr, nil},
+	"alwaysRetainTaskState":           {0x01010203, booleanAttr, nil},
+	"allowTaskReparenting":            {0x01010204, booleanAttr, nil},
+	"minSdkVersion":                   {0x0101020c, integerAttr | stringAttr, nil},
+	"versionCode":                     {0x0101021b, integerAttr, nil},
+	"versionName":                     {0x0101021c, stringAttr, nil},
+	"reqTouchScreen":                  {0x01010227, attrFormatEnum, map[string]uint32{"undefined": 0, "notouch": 1, "stylus": 2, "finger": 3}},
+	"reqKeyboardType":                 {0x01010228, attrFormatEnum, map[string]uint32{"undefined": 0, "nokeys": 1, "qwerty": 2, "twelvekey": 3}},
+	"reqHardKeyboard":                 {0x01010229, booleanAttr, nil},
+	"reqNavigation":                   {0x0101022a, attrFormatEnum, map[string]uint32{"undefined": 0, "nonav": 1, "dpad": 2, "trackball": 3, "wheel": 4}},
+	"windowSoftInputMode":             {0x0101022b, attrFormatFlags, windowSoftInputModes},
+	"noHistory":                       {0x0101022d, booleanAttr, nil},
+	"reqFiveWayNav":                   {0x01010232, booleanAttr, nil},
+	"sharedUserLabel":                 {0x01010261, refAttr, nil},
+	"anyDensity":                      {0x0101026c, booleanAttr, nil},
+	"targetSdkVersion":                {0x01010270, integerAttr | stringAttr, nil},
+	"maxSdkVersion":                   {0x01010271, integerAttr, nil},
+	"testOnly":                        {0x01010272, booleanAttr, nil},
+	"backupAgent":                     {0x0101027f, stringAttr, nil},
+	"allowBackup":                     {0x01010280, booleanAttr, nil},
+	"glEsVersion":                     {0x01010281, integerAttr, nil},
+	"smallScreens":                    {0x01010284, booleanAttr, nil},
+	"normalScreens":                   {0x01010285, booleanAttr, nil},
+	"largeScreens":                    {0x01010286, booleanAttr, nil},
+	"resizeable":                      {0x0101028d, booleanAttr, nil},
+	"required":                        {0x0101028e, booleanAttr, nil},
+	"killAfterRestore":                {0x0101029c, booleanAttr, nil},
+	"restoreNeedsApplication":         {0x0101029d, booleanAttr, nil},
+	"installLocation":                 {0x010102b7, attrFormatEnum, map[string]uint32{"auto": 0, "internalOnly": 1, "preferExternal": 2}},
+	"vmSafeMode":                      {0x010102b8, booleanAttr, nil},
+	"logo":                            {0x010102be, refAttr, nil},
+	"xlargeScreens":                   {0x010102bf, booleanAttr, nil},
+	"hardwareAccelerated":             {0x010102d3, booleanAttr, nil},
+	"largeHeap":                       {0x0101035a, booleanAttr, nil},
+	"requiresSmallestWidthDp":         {0x01010364, integerAttr, nil},
+	"compatibleWidthLimitDp":          {0x01010365, integerAttr, nil},
+	"largestWidthLimitDp":             {0x01010366, integerAttr, nil},
+	"uiOptions":                       {0x01010398, attrFormatFlags, map[string]uint32{"none": 0, "splitActionBarWhenNarrow": 1}},
+	"parentActivityName":              {0x010103a7, stringAttr, nil},
+	"isolatedProcess":                 {0x010103a9, booleanAttr, nil},
+	"supportsRtl":                     {0x010103af, booleanAttr, nil},
+	"banner":                          {0x010103f2, refAttr, nil},
+	"isGame":                          {0x010103f4, booleanAttr, nil},
+	"extractNativeLibs":               {0x010104ea, booleanAttr, nil},
+	"fullBackupContent":               {0x010104eb, refAttr | booleanAttr, nil},
+	"usesCleartextTraffic":            {0x010104ec, booleanAttr, nil},
+	"autoVerify":                      {0x010104ee, booleanAttr, nil},
+	"resizeableActivity":              {0x010104f6, booleanAttr, nil},
+	"defaultToDeviceProtectedStorage": {0x01010504, booleanAttr, nil},
+	"directBootAware":                 {0x01010505, booleanAttr, nil},
+	"networkSecurityConfig":           {0x01010527, refAttr, nil},
+	"roundIcon":                       {0x0101052c, refAttr, nil},
+	"compileSdkVersion":               {0x01010572, integerAttr, nil},
+	"compileSdkVersionCodename":       {0x01010573, stringAttr, nil},
+	"appComponentFactory":             {0x0101057a, stringAttr, nil},
+	"shell":                           {0x01010594, booleanAttr, nil},
+	"foregroundServiceType":           {0x01010599, attrFormatFlags, foregroundServiceTypes},
+	"requestLegacyExternalStorage":    {0x01010603, booleanAttr, nil},
+	"pathSuffix":                      {0x0101061e, stringAttr, nil},
+	"pathAdvancedPattern":             {0x01010620, stringAttr, nil},
+	"dataExtractionRules":             {0x0101063e, refAttr, nil},
+	"usesPermissionFlags":             {0x01010644, attrFormatFlags, usesPermissionFlags},
+}
+
+var protectionLevels = map[string]uint32{
+	"normal":            0x0,
+	"dangerous":         0x1,
+	"signature":         0x2,
+	"signatureOrSystem": 0x3,
+	"privileged":        0x10,
+	"system":            0x10,
+	"development":       0x20,
+	"appop":             0x40,
+	"pre23":             0x80,
+	"installer":         0x100,
+	"verifier":          0x200,
+	"preinstalled":      0x400,
+	"setup":             0x800,
+	"instant":           0x1000,
+	"runtime":           0x2000,
+}
+
+var launchModes = map[string]uint32{
+	"standard":              0,
+	"singleTop":             1,
+	"singleTask":            2,
+	"singleInstance":        3,
+	"singleInstancePerTask": 4,
+}
+
+var screenOrientations = map[string]uint32{
+	"unspecified":      0xffffffff,
+	"landscape":        0,
+	"portrait":         1,
+	"user":             2,
+	"behind":           3,
+	"sensor":           4,
+	"nosensor":         5,
+	"sensorLandscape":  6,
+	"sensorPortrait":   7,
+	"reverseLandscape": 8,
+	"reversePortrait":  9,
+	"fullSensor":       10,
+	"userLandscape":    11,
+	"userPortrait":     12,
+	"fullUser":         13,
+	"locked":           14,
+}
+
+var configChanges = map[string]uint32{
+	"mcc":                  0x0001,
+	"mnc":                  0x0002,
+	"locale":               0x0004,
+	"touchscreen":          0x0008,
+	"keyboard":             0x0010,
+	"keyboardHidden":       0x0020,
+	"navigation":           0x0040,
+	"orientation":          0x0080,
+	"screenLayout":         0x0100,
+	"uiMode":               0x0200,
+	"screenSize":           0x0400,
+	"smallestScreenSize":   0x0800,
+	"density":              0x1000,
+	"layoutDirection":      0x2000,
+	"colorMode":            0x4000,
+	"grammaticalGender":    0x8000,
+	"fontWeightAdjustment": 0x10000000,
+	"fontScale":            0x40000000,
+}
+
+var windowSoftInputModes = map[string]uint32{
+	"stateUnspecified":   0x00,
+	"stateUnchanged":     0x01,
+	"stateHidden":        0x02,
+	"stateAlwaysHidden":  0x03,
+	"stateVisible":       0x04,
+	"stateAlwaysVisible": 0x05,
+	"adjustUnspecified":  0x00,
+	"adjustResize":       0x10,
+	"adjustPan":          0x20,
+	"adjustNothing":      0x30,
+}
+
+var usesPermissionFlags = map[string]uint32{
+	"neverForLocation": 0x10000,
+}
+
+var foregroundServiceTypes = map[string]uint32{
+	"dataSync":        0x01,
+	"mediaPlayback":   0x02,
+	"phoneCall":       0x04,
+	"location":        0x08,
+	"connectedDevice": 0x10,
+	"mediaProjection": 0x20,
+	"camera":          0x40,
+	"microphone":      0x80,
+	"health":          0x100,
+	"remoteMessaging": 0x200,
+	"systemExempted":  0x400,
+	"shortService":    0x800,
+	"fileManagement":  0x1000,
+	"mediaProcessing": 0x2000,
+	"specialUse":      0x40000000,
+}
@@ -0,0 +1,610 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"unicode/utf16"
+)
+
+// Chunk types of Android's binary XML format (see ResourceTypes.h).
+const (
+	resStringPoolType     = 0x0001
+	resXmlType            = 0x0003
+	resXmlStartNamespace  = 0x0100
+	resXmlEndNamespace    = 0x0101
+	resXmlStartElement    = 0x0102
+	resXmlEndElement      = 0x0103
+	resXmlCData           = 0x0104
+	resXmlResourceMapType = 0x0180
+
+	stringPoolUtf8Flag = 1 << 8
+	noIndex            = 0xffffffff
+)
+
+// Data types of Res_value.
+const (
+	resValueNull             = 0x00
+	resValueReference        = 0x01
+	resValueAttribute        = 0x02
+	resValueString           = 0x03
+	resValueFloat            = 0x04
+	resValueDimension        = 0x05
+	resValueFraction         = 0x06
+	resValueDynamicReference = 0x07
+	resValueDynamicAttribute = 0x08
+	resValueIntDec           = 0x10
+	resValueIntHex           = 0x11
+	resValueIntBoolean       = 0x12
+	resValueIntColorArgb8    = 0x1c
+	resValueIntColorRgb8     = 0x1d
+	resValueIntColorArgb4    = 0x1e
+	resValueIntColorRgb4     = 0x1f
+)
+
+var errInvalidBinaryXml = errors.New("invalid binary XML")
+
+// isBinaryXml checks for the header of a binary XML (AXML) chunk.
+func isBinaryXml(data []byte) bool {
+	return len(data) >= 8 && binary.LittleEndian.Uint16(data) == resXmlType && binary.LittleEndian.Uint16(data[2:]) == 8
+}
+
+// parseBinaryXml decodes Android's binary XML format (as found in APKs) into an XmlNode tree.
+func parseBinaryXml(data []byte) (*XmlNode, error) {
+	if !isBinaryXml(data) {
+		return nil, errInvalidBinaryXml
+	}
+	var poolStrings []string
+	var resourceIds []uint32
+	var root *XmlNode
+	var stack []*XmlElement
+	var namespaces []*XmlNamespace
+
+	str := func(index uint32) (string, error) {
+		if index == noIndex {
+			return "", nil
+		}
+		if int(index) >= len(poolStrings) {
+			return "", errInvalidBinaryXml
+		}
+		return poolStrings[index], nil
+	}
+
+	end := int(binary.LittleEndian.Uint32(data[4:]))
+	if end > len(data) {
+		return nil, errInvalidBinaryXml
+	}
+	for pos := 8; pos+8 <= end; {
+		chunkType := binary.LittleEndian.Uint16(data[pos:])
+		headerSize := int(binary.LittleEndian.Uint16(data[pos+2:]))
+		size := int(binary.LittleEndian.Uint32(data[pos+4:]))
+		if size < 8 || pos+size > end || headerSize > size {
+			return nil, errInvalidBinaryXml
+		}
+		chunk := data[pos : pos+size]
+		pos += size
+
+		switch chunkType {
+		case resStringPoolType:
+			var err error
+			if poolStrings, err = parseStringPool(chunk); err != nil {
+				return nil, err
+			}
+			continue
+		case resXmlResourceMapType:
+			for i := headerSize; i+4 <= size; i += 4 {
+				resourceIds = append(resourceIds, binary.LittleEndian.Uint32(chunk[i:]))
+			}
+			continue
+		}
+
+		// All remaining chunks are tree nodes with a line number, a comment and a chunk specific extension.
+		if headerSize < 16 {
+			continue
+		}
+		line := binary.LittleEndian.Uint32(chunk[8:])
+		ext := chunk[headerSize:]
+		switch chunkType {
+		case resXmlStartNamespace:
+			if len(ext) < 8 {
+				return nil, errInvalidBinaryXml
+			}
+			prefix, err := str(binary.LittleEndian.Uint32(ext))
+			if err != nil {
+				return nil, err
+			}
+			uri, err := str(binary.LittleEndian.Uint32(ext[4:]))
+			if err != nil {
+				return nil, err
+			}
+			namespaces = append(namespaces, &XmlNamespace{Prefix: prefix, Uri: uri, Source: &SourcePosition{LineNumber: line}})
+		case resXmlStartElement:
+			if len(ext) < 20 {
+				return nil, errInvalidBinaryXml
+			}
+			ns, err := str(binary.LittleEndian.Uint32(ext))
+			if err != nil {
+				return nil, err
+			}
+			name, err := str(binary.LittleEndian.Uint32(ext[4:]))
+			if err != nil {
+				return nil, err
+			}
+			element := &XmlElement{NamespaceDeclaration: namespaces, NamespaceUri: ns, Name: name}
+			namespaces = nil
+			attrStart := int(binary.LittleEndian.Uint16(ext[8:]))
+			attrSize := int(binary.LittleEndian.Uint16(ext[10:]))
+			attrCount := int(binary.LittleEndian.Uint16(ext[12:]))
+			for i := 0; i < attrCount; i++ {
+				offset := attrStart + i*attrSize
+				if offset+20 > len(ext) {
+					return nil, errInvalidBinaryXml
+				}
+				attr, err := parseBinaryXmlAttribute(ext[offset:offset+20], poolStrings, resourceIds, line)
+				if err != nil {
+					return nil, err
+				}
+				element.Attribute = append(element.Attribute, attr)
+			}
+			node := &XmlNode{Node: &XmlNode_Element{Element: element}, Source: &SourcePosition{LineNumber: line}}
+			if len(stack) == 0 {
+				if root != nil {
+					return nil, errInvalidBinaryXml
+				}
+				root = node
+			} else {
+				parent := stack[len(stack)-1]
+				parent.Child = append(parent.Child, node)
+			}
+			stack = append(stack, element)
+		case resXmlEndElement:
+			if len(stack) == 0 {
+				return nil, errInvalidBinaryXml
+			}
+			stack = stack[:len(stack)-1]
+		case resXmlCData:
+			if len(ext) < 4 || len(stack) == 0 {
+				return nil, errInvalidBinaryXml
+			}
+			text, err := str(binary.LittleEndian.Uint32(ext))
+			if err != nil {
+				return nil, err
+			}
+			parent := stack[len(stack)-1]
+			parent.Child = append(parent.Child, &XmlNode{Node: &XmlNode_Text{Text: text}, Source: &SourcePosition{LineNumber: line}})
+		}
+	}
+	if root == nil {
+		return nil, errInvalidBinaryXml
+	}
+	return root, nil
+}
+
+func parseBinaryXmlAttribute(data []byte, poolStrings []string, resourceIds []uint32, line uint32) (*XmlAttribute, error) {
+	attr := &XmlAttribute{Source: &SourcePosition{LineNumber: line}}
+	indexes := []uint32{binary.LittleEndian.Uint32(data), binary.LittleEndian.Uint32(data[4:]), binary.LittleEndian.Uint32(data[8:])}
+	for _, index := range indexes {
+		if index != noIndex && int(index) >= len(poolStrings) {
+			return nil, errInvalidBinaryXml
+		}
+	}
+	if indexes[1] == noIndex {
+		// Unlike the namespace and raw value, the name is required.
+		return nil, errInvalidBinaryXml
+	}
+	if indexes[0] != noIndex {
+		attr.NamespaceUri = poolStrings[indexes[0]]
+	}
+	attr.Name = poolStrings[indexes[1]]
+	if int(indexes[1]) < len(resourceIds) {
+		attr.ResourceId = resourceIds[indexes[1]]
+	}
+	if indexes[2] != noIndex {
+		attr.Value = poolStrings[indexes[2]]
+	}
+
+	dataType := data[15]
+	value := binary.LittleEndian.Uint32(data[16:])
+	if dataType == resValueString {
+		// Like aapt2, string values are only stored as the raw value.
+		if int(value) >= len(poolStrings) {
+			return nil, errInvalidBinaryXml
+		}
+		attr.Value = poolStrings[value]
+		return attr, nil
+	}
+	attr.CompiledItem = itemFromResValue(dataType, value)
+	return attr, nil
+}
+
+// itemFromResValue converts a Res_value into the equivalent compiled Item.
+func itemFromResValue(dataType uint8, data uint32) *Item {
+	prim := func(value isPrimitive_OneofValue) *Item {
+		return &Item{Value: &Item_Prim{Prim: &Primitive{OneofValue: value}}}
+	}
+	switch dataType {
+	case resValueReference, resValueDynamicReference:
+		return &Item{Value: &Item_Ref{Ref: &Reference{Id: data, IsDynamic: boolValue(dataType == resValueDynamicReference)}}}
+	case resValueAttribute, resValueDynamicAttribute:
+		return &Item{Value: &Item_Ref{Ref: &Reference{Type: Reference_ATTRIBUTE, Id: data, IsDynamic: boolValue(dataType == resValueDynamicAttribute)}}}
+	case resValueNull:
+		if data == 1 {
+			return prim(&Primitive_EmptyValue{EmptyValue: &Primitive_EmptyType{}})
+		}
+		return prim(&Primitive_NullValue{NullValue: &Primitive_NullType{}})
+	case resValueFloat:
+		return prim(&Primitive_FloatValue{FloatValue: math.Float32frombits(data)})
+	case resValueDimension:
+		return prim(&Primitive_DimensionValue{DimensionValue: data})
+	case resValueFraction:
+		return prim(&Primitive_FractionValue{FractionValue: data})
+	case resValueIntDec:
+		return prim(&Primitive_IntDecimalValue{IntDecimalValue: int32(data)})
+	case resValueIntHex:
+		return prim(&Primitive_IntHexadecimalValue{IntHexadecimalValue: data})
+	case resValueIntBoolean:
+		return prim(&Primitive_BooleanValue{BooleanValue: data != 0})
+	case resValueIntColorArgb8:
+		return prim(&Primitive_ColorArgb8Value{ColorArgb8Value: data})
+	case resValueIntColorRgb8:
+		return prim(&Primitive_ColorRgb8Value{ColorRgb8Value: data})
+	case resValueIntColorArgb4:
+		return prim(&Primitive_ColorArgb4Value{ColorArgb4Value: data})
+	case resValueIntColorRgb4:
+		return prim(&Primitive_ColorRgb4Value{ColorRgb4Value: data})
+	}
+	return prim(&Primitive_IntHexadecimalValue{IntHexadecimalValue: data})
+}
+
+// resValueFromItem converts a compiled Item into a Res_value. String-like items are added to the string pool.
+func resValueFromItem(item *Item, pool *stringPoolBuilder) (uint8, uint32) {
+	switch v := item.GetValue().(type) {
+	case *Item_Ref:
+		dataType := uint8(resValueReference)
+		if v.Ref.GetType() == Reference_ATTRIBUTE {
+			dataType = resValueAttribute
+		}
+		if v.Ref.GetIsDynamic().GetValue() {
+			dataType += resValueDynamicReference - resValueReference
+		}
+		return dataType, v.Ref.GetId()
+	case *Item_Str:
+		return resValueString, pool.index(v.Str.GetValue())
+	case *Item_RawStr:
+		return resValueString, pool.index(v.RawStr.GetValue())
+	case *Item_StyledStr:
+		return resValueString, pool.index(v.StyledStr.GetValue())
+	case *Item_File:
+		return resValueString, pool.index(v.File.GetPath())
+	case *Item_Id:
+		return resValueIntBoolean, 0
+	case *Item_Prim:
+		switch p := v.Prim.GetOneofValue().(type) {
+		case *Primitive_NullValue:
+			return resValueNull, 0
+		case *Primitive_EmptyValue:
+			return resValueNull, 1
+		case *Primitive_FloatValue:
+			return resValueFloat, math.Float32bits(p.FloatValue)
+		case *Primitive_DimensionValue:
+			return resValueDimension, p.DimensionValue
+		case *Primitive_FractionValue:
+			return resValueFraction, p.FractionValue
+		case *Primitive_IntDecimalValue:
+			return resValueIntDec, uint32(p.IntDecimalValue)
+		case *Primitive_IntHexadecimalValue:
+			return resValueIntHex, p.IntHexadecimalValue
+		case *Primitive_BooleanValue:
+			if p.BooleanValue {
+				return resValueIntBoolean, 0xffffffff
+			}
+			return resValueIntBoolean, 0
+		case *Primitive_ColorArgb8Value:
+			return resValueIntColorArgb8, p.ColorArgb8Value
+		case *Primitive_ColorRgb8Value:
+			return resValueIntColorRgb8, p.ColorRgb8Value
+		case *Primitive_ColorArgb4Value:
+			return resValueIntColorArgb4, p.ColorArgb4Value
+		case *Primitive_ColorRgb4Value:
+			return resValueIntColorRgb4, p.ColorRgb4Value
+		case *Primitive_DimensionValueDeprecated:
+			return resValueDimension, math.Float32bits(p.DimensionValueDeprecated)
+		case *Primitive_FractionValueDeprecated:
+			return resValueFraction, math.Float32bits(p.FractionValueDeprecated)
+		}
+	}
+	return resValueNull, 0
+}
+
+func boolValue(value bool) *Boolean {
+	if !value {
+		return nil
+	}
+	return &Boolean{Value: true}
+}
+
+func parseStringPool(chunk []byte) ([]string, error) {
+	if len(chunk) < 28 {
+		return nil, errInvalidBinaryXml
+	}
+	headerSize := int(binary.LittleEndian.Uint16(chunk[2:]))
+	count := int(binary.LittleEndian.Uint32(chunk[8:]))
+	flags := binary.LittleEndian.Uint32(chunk[16:])
+	stringsStart := int(binary.LittleEndian.Uint32(chunk[20:]))
+	if headerSize+4*count > len(chunk) || stringsStart > len(chunk) {
+		return nil, errInvalidBinaryXml
+	}
+	result := make([]string, count)
+	for i := range result {
+		offset := stringsStart + int(binary.LittleEndian.Uint32(chunk[headerSize+4*i:]))
+		if offset >= len(chunk) {
+			return nil, errInvalidBinaryXml
+		}
+		var err error
+		if flags&stringPoolUtf8Flag != 0 {
+			result[i], err = decodeUtf8PoolString(chunk[offset:])
+		} else {
+			result[i], err = decodeUtf16PoolString(chunk[offset:])
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func decodeUtf8PoolString(data []byte) (string, error) {
+	// The UTF-16 length comes first, followed by the UTF-8 length. Both use one or two bytes.
+	readLength := func(data []byte) (int, int, error) {
+		if len(data) < 1 {
+			return 0, 0, errInvalidBinaryXml
+		}
+		if data[0]&0x80 == 0 {
+			return int(data[0]), 1, nil
+		}
+		if len(data) < 2 {
+			return 0, 0, errInvalidBinaryXml
+		}
+		return int(data[0]&0x7f)<<8 | int(data[1]), 2, nil
+	}
+	_, n1, err := readLength(data)
+	if err != nil {
+		return "", err
+	}
+	length, n2, err := readLength(data[n1:])
+	if err != nil {
+		return "", err
+	}
+	start := n1 + n2
+	if start+length > len(data) {
+		return "", errInvalidBinaryXml
+	}
+	return string(data[start : start+length]), nil
+}
+
+func decodeUtf16PoolString(data []byte) (string, error) {
+	if len(data) < 2 {
+		return "", errInvalidBinaryXml
+	}
+	length := int(binary.LittleEndian.Uint16(data))
+	start := 2
+	if length&0x8000 != 0 {
+		if len(data) < 4 {
+			return "", errInvalidBinaryXml
+		}
+		length = (length&0x7fff)<<16 | int(binary.LittleEndian.Uint16(data[2:]))
+		start = 4
+	}
+	if start+2*length > len(data) {
+		return "", errInvalidBinaryXml
+	}
+	units := make([]uint16, length)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(data[start+2*i:])
+	}
+	return string(utf16.Decode(units)), nil
+}
+
+// stringPoolBuilder assigns string pool indexes. Attribute names with a resource ID come first because the resource
+// map refers to them by index.
+type stringPoolBuilder struct {
+	poolStrings []string
+	resourceIds []uint32
+	indexes     map[string]uint32
+	attrIndexes map[attributeKey]uint32
+}
+
+type attributeKey struct {
+	name string
+	id   uint32
+}
+
+func newStringPoolBuilder(root *XmlElement) *stringPoolBuilder {
+	pool := &stringPoolBuilder{indexes: map[string]uint32{}, attrIndexes: map[attributeKey]uint32{}}
+	var keys []attributeKey
+	var collect func(element *XmlElement)
+	collect = func(element *XmlElement) {
+		for _, attr := range element.Attribute {
+			key := attributeKey{attr.Name, attr.ResourceId}
+			if _, ok := pool.attrIndexes[key]; attr.ResourceId != 0 && !ok {
+				pool.attrIndexes[key] = 0
+				keys = append(keys, key)
+			}
+		}
+		for _, child := range element.Child {
+			if child.GetElement() != nil {
+				collect(child.GetElement())
+			}
+		}
+	}
+	collect(root)
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].id < keys[j].id
+	})
+	for i, key := range keys {
+		pool.poolStrings = append(pool.poolStrings, key.name)
+		pool.resourceIds = append(pool.resourceIds, key.id)
+		pool.attrIndexes[key] = uint32(i)
+	}
+	return pool
+}
+
+func (p *stringPoolBuilder) index(s string) uint32 {
+	if index, ok := p.indexes[s]; ok {
+		return index
+	}
+	index := uint32(len(p.poolStrings))
+	p.poolStrings = append(p.poolStrings, s)
+	p.indexes[s] = index
+	return index
+}
+
+func (p *stringPoolBuilder) optionalIndex(s string) uint32 {
+	if s == "" {
+		return noIndex
+	}
+	return p.index(s)
+}
+
+func (p *stringPoolBuilder) attributeNameIndex(attr *XmlAttribute) uint32 {
+	if attr.ResourceId != 0 {
+		return p.attrIndexes[attributeKey{attr.Name, attr.ResourceId}]
+	}
+	return p.index(attr.Name)
+}
+
+// encode writes the string pool chunk in UTF-16, like aapt2 does for binary XML.
+func (p *stringPoolBuilder) encode() []byte {
+	var data bytes.Buffer
+	offsets := make([]uint32, len(p.poolStrings))
+	for i, s := range p.poolStrings {
+		offsets[i] = uint32(data.Len())
+		units := utf16.Encode([]rune(s))
+		if len(units) > 0x7fff {
+			writeLE(&data, uint16(0x8000|len(units)>>16), uint16(len(units)))
+		} else {
+			writeLE(&data, uint16(len(units)))
+		}
+		writeLE(&data, units, uint16(0))
+	}
+	for data.Len()%4 != 0 {
+		data.WriteByte(0)
+	}
+
+	headerSize := 28
+	var chunk bytes.Buffer
+	writeLE(&chunk, uint16(resStringPoolType), uint16(headerSize), uint32(headerSize+4*len(offsets)+data.Len()),
+		uint32(len(p.poolStrings)), uint32(0), uint32(0), uint32(headerSize+4*len(offsets)), uint32(0), offsets)
+	chunk.Write(data.Bytes())
+	return chunk.Bytes()
+}
+
+// formatBinaryXml encodes the XmlNode tree in Android's binary XML format.
+func formatBinaryXml(node *XmlNode) ([]byte, error) {
+	root := node.GetElement()
+	if root == nil {
+		return nil, fmt.Errorf("missing root element")
+	}
+	pool := newStringPoolBuilder(root)
+	var nodes bytes.Buffer
+	writeBinaryXmlElement(&nodes, node, pool)
+
+	var resourceMap bytes.Buffer
+	writeLE(&resourceMap, uint16(resXmlResourceMapType), uint16(8), uint32(8+4*len(pool.resourceIds)), pool.resourceIds)
+
+	stringPool := pool.encode()
+	var out bytes.Buffer
+	writeLE(&out, uint16(resXmlType), uint16(8), uint32(8+len(stringPool)+resourceMap.Len()+nodes.Len()))
+	out.Write(stringPool)
+	out.Write(resourceMap.Bytes())
+	out.Write(nodes.Bytes())
+	return out.Bytes(), nil
+}
+
+func writeBinaryXmlElement(buf *bytes.Buffer, node *XmlNode, pool *stringPoolBuilder) {
+	element := node.GetElement()
+	line := node.GetSource().GetLineNumber()
+	for _, ns := range element.NamespaceDeclaration {
+		writeLE(buf, uint16(resXmlStartNamespace), uint16(16), uint32(24), ns.GetSource().GetLineNumber(), uint32(noIndex),
+			pool.optionalIndex(ns.Prefix), pool.index(ns.Uri))
+	}
+
+	attrs := sortedBinaryXmlAttributes(element.Attribute)
+	idIndex, classIndex, styleIndex := uint16(0), uint16(0), uint16(0)
+	for i, attr := range attrs {
+		if attr.NamespaceUri != "" {
+			continue
+		}
+		switch attr.Name {
+		case "id":
+			idIndex = uint16(i + 1)
+		case "class":
+			classIndex = uint16(i + 1)
+		case "style":
+			styleIndex = uint16(i + 1)
+		}
+	}
+	writeLE(buf, uint16(resXmlStartElement), uint16(16), uint32(16+20+20*len(attrs)), line, uint32(noIndex),
+		pool.optionalIndex(element.NamespaceUri), pool.index(element.Name),
+		uint16(20), uint16(20), uint16(len(attrs)), idIndex, classIndex, styleIndex)
+	for _, attr := range attrs {
+		rawValue := uint32(noIndex)
+		var dataType uint8
+		var data uint32
+		if attr.CompiledItem != nil {
+			if attr.Value != "" {
+				rawValue = pool.index(attr.Value)
+			}
+			dataType, data = resValueFromItem(attr.CompiledItem, pool)
+		} else {
+			rawValue = pool.index(attr.Value)
+			dataType, data = resValueString, rawValue
+		}
+		writeLE(buf, pool.optionalIndex(attr.NamespaceUri), pool.attributeNameIndex(attr), rawValue,
+			uint16(8), uint8(0), dataType, data)
+	}
+
+	for _, child := range element.Child {
+		if child.GetElement() != nil {
+			writeBinaryXmlElement(buf, child, pool)
+		} else {
+			writeLE(buf, uint16(resXmlCData), uint16(16), uint32(28), child.GetSource().GetLineNumber(), uint32(noIndex),
+				pool.index(child.GetText()), uint16(8), uint8(0), uint8(resValueNull), uint32(0))
+		}
+	}
+
+	writeLE(buf, uint16(resXmlEndElement), uint16(16), uint32(24), line, uint32(noIndex),
+		pool.optionalIndex(element.NamespaceUri), pool.index(element.Name))
+	for i := len(element.NamespaceDeclaration) - 1; i >= 0; i-- {
+		ns := element.NamespaceDeclaration[i]
+		writeLE(buf, uint16(resXmlEndNamespace), uint16(16), uint32(24), ns.GetSource().GetLineNumber(), uint32(noIndex),
+			pool.optionalIndex(ns.Prefix), pool.index(ns.Uri))
+	}
+}
+
+// sortedBinaryXmlAttributes sorts the attributes like aapt2: attributes with a resource ID come first (ordered by ID),
+// followed by all others (ordered by namespace and name). The framework relies on this order when looking up
+// attributes.
+func sortedBinaryXmlAttributes(attrs []*XmlAttribute) []*XmlAttribute {
+	sorted := append([]*XmlAttribute(nil), attrs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.ResourceId != 0 || b.ResourceId != 0 {
+			return b.ResourceId == 0 || (a.ResourceId != 0 && a.ResourceId < b.ResourceId)
+		}
+		if a.NamespaceUri != b.NamespaceUri {
+			return a.NamespaceUri < b.NamespaceUri
+		}
+		return a.Name < b.Name
+	})
+	return sorted
+}
+
+func writeLE(buf *bytes.Buffer, values ...interface{}) {
+	for _, value := range values {
+		binary.Write(buf, binary.LittleEndian, value)
+	}
+}
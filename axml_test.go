@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func readBinaryXmlFixture(t *testing.T) []byte {
+	data, err := ioutil.ReadFile("testdata/AndroidManifest.axml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestBinaryXmlRoundTrip(t *testing.T) {
+	manifest, err := parseBinaryXml(readBinaryXmlFixture(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := getManifestAttribute(manifest, "", "package").GetValue(); got != "com.example.app" {
+		t.Errorf("package = %q, want com.example.app", got)
+	}
+	if got := getVersionCode(manifest); got != 1 {
+		t.Errorf("versionCode = %d, want 1", got)
+	}
+	attr := getManifestAttribute(manifest, namespace, versionCodeAttr)
+	if attr.GetResourceId() != frameworkAttrs[versionCodeAttr].id {
+		t.Errorf("versionCode resource ID = 0x%08x, want 0x%08x", attr.GetResourceId(), frameworkAttrs[versionCodeAttr].id)
+	}
+
+	out, err := formatBinaryXml(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reparsed, err := parseBinaryXml(out)
+	if err != nil {
+		t.Fatalf("failed to parse the formatted XML: %v", err)
+	}
+	if !proto.Equal(manifest, reparsed) {
+		t.Errorf("round trip changed the manifest:\n%v\n%v", manifest, reparsed)
+	}
+}
+
+func TestParseBinaryXmlInvalidAttributeName(t *testing.T) {
+	data := readBinaryXmlFixture(t)
+	for _, name := range []uint32{noIndex, 0xfffff} {
+		corrupted := append([]byte(nil), data...)
+		// Skip the XML header and the string pool and resource map chunks up to the first start element.
+		off := 8
+		for binary.LittleEndian.Uint16(corrupted[off:]) != resXmlStartElement {
+			off += int(binary.LittleEndian.Uint32(corrupted[off+4:]))
+		}
+		// The first attribute follows the 16 byte chunk header and the 20 byte element header. Its name is the
+		// second field after the namespace.
+		binary.LittleEndian.PutUint32(corrupted[off+16+20+4:], name)
+		if _, err := parseBinaryXml(corrupted); err != errInvalidBinaryXml {
+			t.Errorf("name index 0x%x: got error %v, want %v", name, err, errInvalidBinaryXml)
+		}
+	}
+}
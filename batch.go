@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// fileResult is the outcome of processing one of multiple files.
+type fileResult struct {
+	path     string
+	fileType inputType
+	duration time.Duration
+	err      error
+}
+
+// expandPaths expands glob patterns like "build/*/*.aab" and removes duplicates. Patterns without any match are an
+// error, so typos don't go unnoticed.
+func expandPaths(args []string) ([]string, error) {
+	var paths []string
+	seen := map[string]bool{}
+	for _, arg := range args {
+		matches := []string{arg}
+		if strings.ContainsAny(arg, "*?[") {
+			var err error
+			if matches, err = filepath.Glob(arg); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", arg, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no files match %q", arg)
+			}
+		}
+		for _, path := range matches {
+			if !seen[path] {
+				seen[path] = true
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths, nil
+}
+
+// processFiles processes the files concurrently with the given number of workers. The messages of each file are
+// buffered and printed as a block once the file is done, so the output of different files isn't interleaved.
+func processFiles(paths []string, fileType inputType, config *Config, jobs int) []*fileResult {
+	results := make([]*fileResult, len(paths))
+	indexes := make(chan int)
+	var outputMutex sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < jobs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				var output bytes.Buffer
+				fileConfig := *config
+				fileConfig.out = &output
+
+				result := &fileResult{path: paths[index]}
+				start := time.Now()
+				result.fileType, result.err = processFile(result.path, fileType, &fileConfig)
+				result.duration = time.Since(start)
+				results[index] = result
+
+				outputMutex.Lock()
+				fmt.Fprintf(config.out, "== %s\n", result.path)
+				config.out.Write(output.Bytes())
+				if result.err != nil {
+					fmt.Fprintln(config.out, "Error:", result.err)
+				}
+				outputMutex.Unlock()
+			}
+		}()
+	}
+	for i := range paths {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// printSummary prints a table with the result of every file and returns the number of failed files.
+func printSummary(results []*fileResult) int {
+	failed := 0
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "FILE\tTYPE\tTIME\tRESULT")
+	for _, result := range results {
+		status := "ok"
+		if result.err != nil {
+			failed++
+			status = "FAILED: " + strings.SplitN(result.err.Error(), "\n", 2)[0]
+		}
+		fileType := result.fileType.String()
+		if result.fileType == inputTypeAuto {
+			fileType = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%.1fs\t%s\n", result.path, fileType, result.duration.Seconds(), status)
+	}
+	w.Flush()
+	fmt.Printf("%d of %d files failed\n", failed, len(results))
+	return failed
+}
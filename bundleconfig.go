@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const bundleConfigPath = "BundleConfig.pb"
+
+// A bundleConfigEdit modifies a single setting in the BundleConfig.
+type bundleConfigEdit func(bundleConfig *BundleConfig, out io.Writer)
+
+var splitDimensionAliases = map[string]SplitDimension_Value{
+	"abi":      SplitDimension_ABI,
+	"density":  SplitDimension_SCREEN_DENSITY,
+	"language": SplitDimension_LANGUAGE,
+	"texture":  SplitDimension_TEXTURE_COMPRESSION_FORMAT,
+}
+
+// parseBundleConfigEdit parses an edit expression of the form "key=value", "key+=value" or "key-=value".
+//
+// Supported keys:
+//
+//	split.<dimension>=true|false           enable/disable splitting by abi, density, language, texture, ...
+//	uncompressedGlob+=<glob>               leave matching files uncompressed in the APKs
+//	uncompressedGlob-=<glob>               remove a previously added glob
+//	uncompressNativeLibraries=true|false
+//	uncompressDexFiles=true|false
+//	injectLocaleConfig=true|false
+//	bundletoolVersion=<version>
+func parseBundleConfigEdit(expr string) (bundleConfigEdit, error) {
+	i := strings.Index(expr, "=")
+	if i <= 0 {
+		return nil, fmt.Errorf("invalid bundle config edit %q: expected key=value", expr)
+	}
+	key, value := expr[:i], expr[i+1:]
+	op := "="
+	if strings.HasSuffix(key, "+") || strings.HasSuffix(key, "-") {
+		op = key[len(key)-1:] + op
+		key = key[:len(key)-1]
+	}
+
+	if strings.HasPrefix(key, "split.") && op == "=" {
+		dimension, err := parseSplitDimension(strings.TrimPrefix(key, "split."))
+		if err != nil {
+			return nil, err
+		}
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %q", key, value)
+		}
+		return func(bundleConfig *BundleConfig, out io.Writer) {
+			setSplitDimension(bundleConfig, dimension, enabled, out)
+		}, nil
+	}
+
+	switch {
+	case key == "uncompressedGlob" && op == "+=":
+		return func(bundleConfig *BundleConfig, out io.Writer) {
+			if bundleConfig.Compression == nil {
+				bundleConfig.Compression = &Compression{}
+			}
+			for _, glob := range bundleConfig.Compression.UncompressedGlob {
+				if glob == value {
+					return
+				}
+			}
+			fmt.Fprintln(out, "Adding uncompressed glob", value)
+			bundleConfig.Compression.UncompressedGlob = append(bundleConfig.Compression.UncompressedGlob, value)
+		}, nil
+	case key == "uncompressedGlob" && op == "-=":
+		return func(bundleConfig *BundleConfig, out io.Writer) {
+			globs := bundleConfig.GetCompression().GetUncompressedGlob()
+			for i, glob := range globs {
+				if glob == value {
+					fmt.Fprintln(out, "Removing uncompressed glob", value)
+					bundleConfig.Compression.UncompressedGlob = append(globs[:i:i], globs[i+1:]...)
+					return
+				}
+			}
+		}, nil
+	case op != "=":
+		return nil, fmt.Errorf("invalid bundle config edit %q: %s is not supported for %s", expr, op, key)
+	case key == "bundletoolVersion":
+		return func(bundleConfig *BundleConfig, out io.Writer) {
+			if bundleConfig.Bundletool == nil {
+				bundleConfig.Bundletool = &Bundletool{}
+			}
+			fmt.Fprintln(out, "Changing bundletool version from", bundleConfig.Bundletool.Version, "to", value)
+			bundleConfig.Bundletool.Version = value
+		}, nil
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %s: %q", key, value)
+	}
+	switch key {
+	case "uncompressNativeLibraries":
+		return func(bundleConfig *BundleConfig, out io.Writer) {
+			optimizations := getOptimizations(bundleConfig)
+			if optimizations.UncompressNativeLibraries == nil {
+				optimizations.UncompressNativeLibraries = &UncompressNativeLibraries{}
+			}
+			fmt.Fprintln(out, "Changing uncompressNativeLibraries from", optimizations.UncompressNativeLibraries.Enabled, "to", enabled)
+			optimizations.UncompressNativeLibraries.Enabled = enabled
+		}, nil
+	case "uncompressDexFiles":
+		return func(bundleConfig *BundleConfig, out io.Writer) {
+			optimizations := getOptimizations(bundleConfig)
+			if optimizations.UncompressDexFiles == nil {
+				optimizations.UncompressDexFiles = &UncompressDexFiles{}
+			}
+			fmt.Fprintln(out, "Changing uncompressDexFiles from", optimizations.UncompressDexFiles.Enabled, "to", enabled)
+			optimizations.UncompressDexFiles.Enabled = enabled
+		}, nil
+	case "injectLocaleConfig":
+		return func(bundleConfig *BundleConfig, out io.Writer) {
+			if bundleConfig.Locales == nil {
+				bundleConfig.Locales = &Locales{}
+			}
+			fmt.Fprintln(out, "Changing injectLocaleConfig from", bundleConfig.Locales.InjectLocaleConfig, "to", enabled)
+			bundleConfig.Locales.InjectLocaleConfig = enabled
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown bundle config key %q", key)
+}
+
+func parseSplitDimension(name string) (SplitDimension_Value, error) {
+	if dimension, ok := splitDimensionAliases[strings.ToLower(name)]; ok {
+		return dimension, nil
+	}
+	if dimension, ok := SplitDimension_Value_value[strings.ToUpper(name)]; ok && dimension != 0 {
+		return SplitDimension_Value(dimension), nil
+	}
+	return 0, fmt.Errorf("unknown split dimension %q", name)
+}
+
+func getOptimizations(bundleConfig *BundleConfig) *Optimizations {
+	if bundleConfig.Optimizations == nil {
+		bundleConfig.Optimizations = &Optimizations{}
+	}
+	return bundleConfig.Optimizations
+}
+
+func setSplitDimension(bundleConfig *BundleConfig, dimension SplitDimension_Value, enabled bool, out io.Writer) {
+	optimizations := getOptimizations(bundleConfig)
+	if optimizations.SplitsConfig == nil {
+		optimizations.SplitsConfig = &SplitsConfig{}
+	}
+	splitsConfig := optimizations.SplitsConfig
+	for _, split := range splitsConfig.SplitDimension {
+		if split.Value == dimension {
+			fmt.Fprintln(out, "Changing", dimension, "split from", !split.Negate, "to", enabled)
+			split.Negate = !enabled
+			return
+		}
+	}
+	fmt.Fprintln(out, "Setting", dimension, "split to", enabled)
+	splitsConfig.SplitDimension = append(splitsConfig.SplitDimension, &SplitDimension{
+		Value:  dimension,
+		Negate: !enabled,
+	})
+}
+
+func updateBundleConfig(in []byte, config *Config) ([]byte, error) {
+	bundleConfig := &BundleConfig{}
+	if err := bundleConfig.UnmarshalVT(in); err != nil {
+		return nil, fmt.Errorf("failed to parse BundleConfig: %w", err)
+	}
+	for _, edit := range config.bundleConfigEdits {
+		edit(bundleConfig, config.out)
+	}
+
+	out, err := bundleConfig.MarshalVT()
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling BundleConfig: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,494 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// resourceLinker assigns resource IDs and compiled items to XML attributes like aapt2 compile/link does for
+// manifests, and resolves IDs back to names when decompiling.
+type resourceLinker struct {
+	// The package of the app's resources, used for references without package like @string/app_name.
+	packageName string
+	// Attribute definitions of the android: namespace by name.
+	attrs map[string]*attrDef
+	// Resource IDs by their fully qualified name like android:style/Theme.
+	ids   map[string]uint32
+	names map[uint32]string
+}
+
+func newResourceLinker() *resourceLinker {
+	l := &resourceLinker{
+		attrs: map[string]*attrDef{},
+		ids:   map[string]uint32{},
+		names: map[uint32]string{},
+	}
+	for name, attr := range frameworkAttrs {
+		l.attrs[name] = attr
+		l.ids["android:attr/"+name] = attr.id
+		l.names[attr.id] = "android:attr/" + name
+	}
+	return l
+}
+
+// loadResources records the app's resources from a resources.pb or from the base module of an AAB.
+func (l *resourceLinker) loadResources(path string) error {
+	var data []byte
+	var err error
+	if strings.HasSuffix(path, ".aab") {
+		data, err = readFromZip(path, "base/"+resourceTableName)
+	} else {
+		data, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return err
+	}
+	return l.loadResourceTable(data)
+}
+
+// loadResourceTable records the resources of a serialized resources.pb.
+func (l *resourceLinker) loadResourceTable(data []byte) error {
+	table := &ResourceTable{}
+	if err := table.UnmarshalVT(data); err != nil {
+		return err
+	}
+	for _, pkg := range table.Package {
+		if l.packageName == "" {
+			l.packageName = pkg.PackageName
+		}
+		for _, t := range pkg.Type {
+			for _, entry := range t.Entry {
+				id := pkg.GetPackageId().GetId()<<24 | t.GetTypeId().GetId()<<16 | entry.GetEntryId().GetId()
+				name := pkg.PackageName + ":" + t.Name + "/" + entry.Name
+				l.ids[name] = id
+				l.names[id] = name
+			}
+		}
+	}
+	return nil
+}
+
+// compileXml links all attributes of the XML tree. Attributes in the tools: namespace are removed because aapt2
+// strips them, too.
+func (l *resourceLinker) compileXml(node *XmlNode) error {
+	if l.packageName == "" {
+		l.packageName = getManifestAttribute(node, "", "package").GetValue()
+	}
+	var errs []string
+	l.compileElement(node, &errs)
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+func (l *resourceLinker) compileElement(node *XmlNode, errs *[]string) {
+	element := node.GetElement()
+	if element == nil {
+		return
+	}
+	var attrs []*XmlAttribute
+	for _, attr := range element.Attribute {
+		if attr.NamespaceUri == toolsNamespace {
+			continue
+		}
+		if err := l.compileAttribute(attr); err != nil {
+			*errs = append(*errs, fmt.Sprintf("line %d: <%s>: %v", node.GetSource().GetLineNumber(), element.Name, err))
+		}
+		attrs = append(attrs, attr)
+	}
+	element.Attribute = attrs
+	for _, child := range element.Child {
+		l.compileElement(child, errs)
+	}
+}
+
+func (l *resourceLinker) compileAttribute(attr *XmlAttribute) error {
+	if attr.Value == "" && attr.CompiledItem != nil {
+		// Already compiled, e.g. when converting binary XML into proto XML.
+		attr.Value = formatItem(attr.CompiledItem)
+	}
+	attr.ResourceId = 0
+	attr.CompiledItem = nil
+	if attr.NamespaceUri != namespace {
+		// Without an attribute definition only references are compiled.
+		if isReference(attr.Value) {
+			item, err := l.compileReference(strings.TrimSpace(attr.Value))
+			if err != nil {
+				return err
+			}
+			attr.CompiledItem = item
+		}
+		return nil
+	}
+
+	def := l.attrs[attr.Name]
+	if def == nil {
+		return fmt.Errorf("attribute android:%s not found (use -android-jar for the complete list of attributes)", attr.Name)
+	}
+	attr.ResourceId = def.id
+	item, err := l.compileValue(attr.Value, def)
+	if err != nil {
+		return fmt.Errorf("android:%s=%q: %v", attr.Name, attr.Value, err)
+	}
+	attr.CompiledItem = item
+	return nil
+}
+
+// compileValue parses the value according to the attribute's formats in the same order as aapt2. A nil item means
+// that the value is kept as plain string.
+func (l *resourceLinker) compileValue(value string, def *attrDef) (*Item, error) {
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "@null":
+		// Like aapt2, @null is a reference to 0 because TYPE_NULL would be treated as an error at runtime.
+		return &Item{Value: &Item_Ref{Ref: &Reference{}}}, nil
+	case value == "@empty":
+		return primItem(&Primitive_EmptyValue{EmptyValue: &Primitive_EmptyType{}}), nil
+	case isReference(value):
+		return l.compileReference(value)
+	}
+
+	if def.format&attrFormatEnum != 0 {
+		if symbol, ok := def.symbols[value]; ok {
+			return primItem(&Primitive_IntDecimalValue{IntDecimalValue: int32(symbol)}), nil
+		}
+	}
+	if def.format&attrFormatFlags != 0 {
+		if flags, ok := parseFlags(value, def.symbols); ok {
+			return primItem(&Primitive_IntHexadecimalValue{IntHexadecimalValue: flags}), nil
+		}
+	}
+	if def.format&attrFormatColor != 0 {
+		if item := parseColor(value); item != nil {
+			return item, nil
+		}
+	}
+	if def.format&attrFormatBoolean != 0 {
+		switch value {
+		case "true", "TRUE", "True":
+			return primItem(&Primitive_BooleanValue{BooleanValue: true}), nil
+		case "false", "FALSE", "False":
+			return primItem(&Primitive_BooleanValue{BooleanValue: false}), nil
+		}
+	}
+	if def.format&attrFormatInteger != 0 {
+		if item := parseInteger(value); item != nil {
+			return item, nil
+		}
+	}
+	if def.format&(attrFormatFloat|attrFormatDimension|attrFormatFraction) != 0 {
+		if item := parseFloat(value, def.format); item != nil {
+			return item, nil
+		}
+	}
+	if def.format&attrFormatString != 0 {
+		return nil, nil
+	}
+	return nil, fmt.Errorf("expected %s", formatNames(def.format))
+}
+
+func isReference(value string) bool {
+	value = strings.TrimSpace(value)
+	return strings.HasPrefix(value, "@") || strings.HasPrefix(value, "?")
+}
+
+// compileReference resolves references like @string/app_name, @android:style/Theme or ?android:attr/colorPrimary.
+func (l *resourceLinker) compileReference(value string) (*Item, error) {
+	ref := &Reference{}
+	name := strings.TrimLeft(value[1:], "+*")
+	if value[0] == '?' {
+		ref.Type = Reference_ATTRIBUTE
+	}
+	if strings.HasPrefix(name, "0x") {
+		// Numeric references like @0x7f010000, as decompiled from binary XML without resources.
+		id, err := strconv.ParseUint(name[2:], 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reference %s", value)
+		}
+		ref.Id = uint32(id)
+		return &Item{Value: &Item_Ref{Ref: ref}}, nil
+	}
+	pkg := l.packageName
+	if i := strings.Index(name, ":"); i >= 0 {
+		pkg, name = name[:i], name[i+1:]
+	}
+	if !strings.Contains(name, "/") {
+		if ref.Type != Reference_ATTRIBUTE {
+			return nil, fmt.Errorf("invalid reference %s", value)
+		}
+		name = "attr/" + name
+	}
+	id, ok := l.ids[pkg+":"+name]
+	if !ok {
+		if pkg == "android" {
+			return nil, fmt.Errorf("resource %s not found (use -android-jar to resolve framework resources)", value)
+		}
+		return nil, fmt.Errorf("resource %s not found (use -resources to resolve the app's resources)", value)
+	}
+	ref.Id = id
+	ref.Name = name
+	if pkg == "android" {
+		ref.Name = "android:" + name
+	}
+	return &Item{Value: &Item_Ref{Ref: ref}}, nil
+}
+
+// decompileXml adds the names of referenced resources, which are missing in binary XML.
+func (l *resourceLinker) decompileXml(node *XmlNode) {
+	element := node.GetElement()
+	for _, attr := range element.GetAttribute() {
+		ref := attr.GetCompiledItem().GetRef()
+		if ref == nil || ref.Name != "" || ref.Id == 0 {
+			continue
+		}
+		if name, ok := l.names[ref.Id]; ok {
+			ref.Name = strings.TrimPrefix(name, l.packageName+":")
+			attr.Value = ""
+		}
+	}
+	for _, child := range element.GetChild() {
+		l.decompileXml(child)
+	}
+}
+
+func primItem(value isPrimitive_OneofValue) *Item {
+	return &Item{Value: &Item_Prim{Prim: &Primitive{OneofValue: value}}}
+}
+
+func parseFlags(value string, symbols map[string]uint32) (uint32, bool) {
+	var flags uint32
+	for _, part := range strings.Split(value, "|") {
+		symbol, ok := symbols[strings.TrimSpace(part)]
+		if !ok {
+			return 0, false
+		}
+		flags |= symbol
+	}
+	return flags, true
+}
+
+func parseColor(value string) *Item {
+	if !strings.HasPrefix(value, "#") {
+		return nil
+	}
+	c, err := strconv.ParseUint(value[1:], 16, 32)
+	if err != nil {
+		return nil
+	}
+	color := uint32(c)
+	// Duplicates each 4-bit channel into 8 bits.
+	expand := func(c uint32) uint32 {
+		var result uint32
+		for i := uint(0); i < 4; i++ {
+			nibble := c >> (4 * i) & 0xf
+			result |= (nibble<<4 | nibble) << (8 * i)
+		}
+		return result
+	}
+	switch len(value) - 1 {
+	case 3:
+		return primItem(&Primitive_ColorRgb4Value{ColorRgb4Value: 0xff000000 | expand(color)})
+	case 4:
+		return primItem(&Primitive_ColorArgb4Value{ColorArgb4Value: expand(color)})
+	case 6:
+		return primItem(&Primitive_ColorRgb8Value{ColorRgb8Value: 0xff000000 | color})
+	case 8:
+		return primItem(&Primitive_ColorArgb8Value{ColorArgb8Value: color})
+	}
+	return nil
+}
+
+func parseInteger(value string) *Item {
+	if strings.HasPrefix(value, "0x") || strings.HasPrefix(value, "0X") {
+		hex, err := strconv.ParseUint(value[2:], 16, 32)
+		if err != nil {
+			return nil
+		}
+		return primItem(&Primitive_IntHexadecimalValue{IntHexadecimalValue: uint32(hex)})
+	}
+	dec, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return nil
+	}
+	return primItem(&Primitive_IntDecimalValue{IntDecimalValue: int32(dec)})
+}
+
+var dimensionUnitValues = map[string]uint32{"px": 0, "dip": 1, "dp": 1, "sp": 2, "pt": 3, "in": 4, "mm": 5}
+
+// parseFloat parses floats, dimensions like 16dp and fractions like 50%p if the format allows them.
+func parseFloat(value string, format uint32) *Item {
+	end := len(value)
+	for end > 0 && strings.IndexByte("0123456789.", value[end-1]) < 0 {
+		end--
+	}
+	number, err := strconv.ParseFloat(value[:end], 32)
+	if err != nil {
+		return nil
+	}
+	unit := value[end:]
+	if unit == "" && format&attrFormatFloat != 0 {
+		return primItem(&Primitive_FloatValue{FloatValue: float32(number)})
+	}
+	if u, ok := dimensionUnitValues[unit]; ok && format&attrFormatDimension != 0 {
+		return primItem(&Primitive_DimensionValue{DimensionValue: complexValue(number, u)})
+	}
+	if format&attrFormatFraction != 0 {
+		switch unit {
+		case "%":
+			return primItem(&Primitive_FractionValue{FractionValue: complexValue(number/100, 0)})
+		case "%p":
+			return primItem(&Primitive_FractionValue{FractionValue: complexValue(number/100, 1)})
+		}
+	}
+	return nil
+}
+
+// complexValue encodes a number in the Res_value complex format like ResTable::stringToFloat does.
+func complexValue(number float64, unit uint32) uint32 {
+	negative := number < 0
+	bits := uint64(math.Abs(number)*(1<<23) + 0.5)
+	var radix, shift uint
+	switch {
+	case bits&0x7fffff == 0:
+		radix, shift = 0, 23
+	case bits&^uint64(0x7fffff) == 0:
+		radix, shift = 3, 0
+	case bits&^uint64(0x7fffffff) == 0:
+		radix, shift = 2, 8
+	case bits&^uint64(0x7fffffffff) == 0:
+		radix, shift = 1, 16
+	default:
+		radix, shift = 0, 39
+	}
+	mantissa := int32(bits>>shift) & 0xffffff
+	if negative {
+		mantissa = -mantissa & 0xffffff
+	}
+	return uint32(mantissa)<<8 | uint32(radix)<<4 | unit
+}
+
+func formatNames(format uint32) string {
+	var names []string
+	for _, f := range []struct {
+		flag uint32
+		name string
+	}{
+		{attrFormatReference, "reference"},
+		{attrFormatString, "string"},
+		{attrFormatInteger, "integer"},
+		{attrFormatBoolean, "boolean"},
+		{attrFormatColor, "color"},
+		{attrFormatFloat, "float"},
+		{attrFormatDimension, "dimension"},
+		{attrFormatFraction, "fraction"},
+		{attrFormatEnum, "enum"},
+		{attrFormatFlags, "flags"},
+	} {
+		if format&f.flag != 0 {
+			names = append(names, f.name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// parseLinkerFlags adds the flags for loading the framework and app resources to the FlagSet.
+func parseLinkerFlags(flags *flag.FlagSet, args []string) *resourceLinker {
+	androidJar := flags.String("android-jar", "", "The android.jar from the Android SDK to resolve all framework attributes and resources")
+	resources := flags.String("resources", "", "A resources.pb or AAB to resolve the app's resources")
+	flags.Parse(args)
+
+	linker := newResourceLinker()
+	if *androidJar != "" {
+		if err := linker.loadAndroidJar(*androidJar); err != nil {
+			log.Fatalln("Failed to load", *androidJar+":", err)
+		}
+	}
+	if *resources != "" {
+		if err := linker.loadResources(*resources); err != nil {
+			log.Fatalln("Failed to load", *resources+":", err)
+		}
+	}
+	return linker
+}
+
+func commandFlags(name string, usage string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s %s %s\n", os.Args[0], name, usage)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// compileCommand converts a text XML file into proto or binary XML.
+func compileCommand(args []string) {
+	flags := commandFlags("compile", "[flags] AndroidManifest.xml output")
+	format := flags.String("format", "proto", "The output format: proto (AAB) or binary (APK)")
+	linker := parseLinkerFlags(flags, args)
+	if flags.NArg() != 2 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	outputFormat := xmlFormatProto
+	switch *format {
+	case "proto":
+	case "binary":
+		outputFormat = xmlFormatBinary
+	default:
+		fmt.Fprintln(flags.Output(), "Error: unknown format", *format)
+		os.Exit(2)
+	}
+
+	in, err := ioutil.ReadFile(flags.Arg(0))
+	if err != nil {
+		log.Fatalln("Error reading file:", err)
+	}
+	xmlNode, err := decodeXml(in, detectXmlFormat(in))
+	if err != nil {
+		log.Fatalln("Failed to parse XML:", err)
+	}
+	if err := linker.compileXml(xmlNode); err != nil {
+		log.Fatalln("Failed to compile", flags.Arg(0)+":\n"+err.Error())
+	}
+	out, err := encodeXml(xmlNode, outputFormat)
+	if err != nil {
+		log.Fatalln("Error marshalling XML:", err)
+	}
+	if err := ioutil.WriteFile(flags.Arg(1), out, 0644); err != nil {
+		log.Fatalln("Error writing file:", err)
+	}
+}
+
+// decompileCommand converts a proto or binary XML file into text XML.
+func decompileCommand(args []string) {
+	flags := commandFlags("decompile", "[flags] AndroidManifest.xml output.xml")
+	linker := parseLinkerFlags(flags, args)
+	if flags.NArg() != 2 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	in, err := ioutil.ReadFile(flags.Arg(0))
+	if err != nil {
+		log.Fatalln("Error reading file:", err)
+	}
+	xmlNode, err := decodeXml(in, detectXmlFormat(in))
+	if err != nil {
+		log.Fatalln("Failed to parse XML:", err)
+	}
+	if linker.packageName == "" {
+		linker.packageName = getManifestAttribute(xmlNode, "", "package").GetValue()
+	}
+	linker.decompileXml(xmlNode)
+	if err := ioutil.WriteFile(flags.Arg(1), formatTextXml(xmlNode), 0644); err != nil {
+		log.Fatalln("Error writing file:", err)
+	}
+}
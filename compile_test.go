@@ -0,0 +1,104 @@
+package main
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCompileValue(t *testing.T) {
+	linker := newResourceLinker()
+	linker.packageName = "com.example.app"
+	linker.ids["com.example.app:string/app_name"] = 0x7f010000
+	dimension := &attrDef{format: attrFormatDimension}
+	float := &attrDef{format: attrFormatFloat}
+	color := &attrDef{format: attrFormatColor}
+	tests := []struct {
+		value string
+		def   *attrDef
+		want  *Item
+	}{
+		{"@null", frameworkAttrs["label"], &Item{Value: &Item_Ref{Ref: &Reference{}}}},
+		{"@empty", frameworkAttrs["label"], primItem(&Primitive_EmptyValue{EmptyValue: &Primitive_EmptyType{}})},
+		{"@string/app_name", frameworkAttrs["label"], &Item{Value: &Item_Ref{Ref: &Reference{Id: 0x7f010000, Name: "string/app_name"}}}},
+		{"@0x7f010001", frameworkAttrs["label"], &Item{Value: &Item_Ref{Ref: &Reference{Id: 0x7f010001}}}},
+		{"My App", frameworkAttrs["label"], nil},
+		{"portrait", frameworkAttrs["screenOrientation"], primItem(&Primitive_IntDecimalValue{IntDecimalValue: 1})},
+		{"orientation|keyboardHidden", frameworkAttrs["configChanges"], primItem(&Primitive_IntHexadecimalValue{IntHexadecimalValue: 0xa0})},
+		{"True", frameworkAttrs["debuggable"], primItem(&Primitive_BooleanValue{BooleanValue: true})},
+		{" 42 ", frameworkAttrs["versionCode"], primItem(&Primitive_IntDecimalValue{IntDecimalValue: 42})},
+		{"0x10", frameworkAttrs["versionCode"], primItem(&Primitive_IntHexadecimalValue{IntHexadecimalValue: 0x10})},
+		{"Tiramisu", frameworkAttrs["minSdkVersion"], nil},
+		{"16dp", dimension, primItem(&Primitive_DimensionValue{DimensionValue: 0x1001})},
+		{"1.5", float, primItem(&Primitive_FloatValue{FloatValue: 1.5})},
+		{"#f00", color, primItem(&Primitive_ColorRgb4Value{ColorRgb4Value: 0xffff0000})},
+	}
+	for _, test := range tests {
+		item, err := linker.compileValue(test.value, test.def)
+		if err != nil {
+			t.Errorf("compileValue(%q) failed: %v", test.value, err)
+			continue
+		}
+		if !proto.Equal(item, test.want) {
+			t.Errorf("compileValue(%q) = %v, want %v", test.value, item, test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		value string
+		def   *attrDef
+	}{
+		{"@string/missing", frameworkAttrs["label"]},
+		{"@android:style/Theme", frameworkAttrs["label"]},
+		{"yes", frameworkAttrs["debuggable"]},
+		{"sideways", frameworkAttrs["screenOrientation"]},
+		{"16", dimension},
+		{"12.5x", float},
+	} {
+		if item, err := linker.compileValue(test.value, test.def); err == nil {
+			t.Errorf("compileValue(%q) = %v, want an error", test.value, item)
+		}
+	}
+}
+
+func TestComplexValue(t *testing.T) {
+	tests := []struct {
+		number float64
+		unit   uint32
+		want   uint32
+	}{
+		{0, 1, 0x00000001},
+		{16, 1, 0x00001001},
+		{-16, 1, 0xfffff001},
+		{1.5, 1, 0x00c00021},
+		{0.5, 0, 0x40000030},
+		{0.5, 1, 0x40000031},
+		{1.0 / 3, 0, 0x2aaaab30},
+	}
+	for _, test := range tests {
+		if got := complexValue(test.number, test.unit); got != test.want {
+			t.Errorf("complexValue(%v, %d) = 0x%08x, want 0x%08x", test.number, test.unit, got, test.want)
+		}
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		value string
+		want  *Item
+	}{
+		{"#f00", primItem(&Primitive_ColorRgb4Value{ColorRgb4Value: 0xffff0000})},
+		{"#8f0a", primItem(&Primitive_ColorArgb4Value{ColorArgb4Value: 0x88ff00aa})},
+		{"#12ab34", primItem(&Primitive_ColorRgb8Value{ColorRgb8Value: 0xff12ab34})},
+		{"#8012ab34", primItem(&Primitive_ColorArgb8Value{ColorArgb8Value: 0x8012ab34})},
+		{"#12345", nil},
+		{"#xyz", nil},
+		{"f00", nil},
+		{"#", nil},
+	}
+	for _, test := range tests {
+		if got := parseColor(test.value); !proto.Equal(got, test.want) {
+			t.Errorf("parseColor(%q) = %v, want %v", test.value, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// componentTags are the <application> children which declare components.
+var componentTags = []string{"activity", "activity-alias", "service", "receiver", "provider"}
+
+// componentAttrs are the attributes which can be changed with --component.
+var componentAttrs = []string{"enabled", "exported", "permission", "process"}
+
+// componentSpec is a component edit given as name,attr=value[,attr=value...], e.g. .DebugActivity,enabled=false.
+type componentSpec struct {
+	name  string
+	attrs []attributeSpec
+}
+
+func parseComponentSpec(spec string) (componentSpec, error) {
+	parts := strings.Split(spec, ",")
+	c := componentSpec{name: strings.TrimSpace(parts[0])}
+	if c.name == "" || len(parts) < 2 {
+		return c, fmt.Errorf("invalid component %q (expected name,attribute=value)", spec)
+	}
+	for _, part := range parts[1:] {
+		attr, err := parseAttributeSpec(part)
+		if err != nil {
+			return c, fmt.Errorf("invalid component %q: %w", spec, err)
+		}
+		if !containsString(componentAttrs, attr.name) {
+			return c, fmt.Errorf("invalid component %q: unsupported attribute %s (expected %s)", spec, attr.name, strings.Join(componentAttrs, ", "))
+		}
+		c.attrs = append(c.attrs, attr)
+	}
+	return c, nil
+}
+
+// qualifiedClassName resolves class names like .MainActivity or MainActivity against the package like the
+// platform does.
+func qualifiedClassName(name string, packageName string) string {
+	if strings.HasPrefix(name, ".") {
+		return packageName + name
+	}
+	if !strings.Contains(name, ".") {
+		return packageName + "." + name
+	}
+	return name
+}
+
+// findComponents returns the components with the given (possibly relative) class name.
+func findComponents(root *XmlElement, packageName string, name string) []elementMatch {
+	className := qualifiedClassName(name, packageName)
+	var matches []elementMatch
+	for _, application := range childElements(root, "application") {
+		for _, child := range application.GetChild() {
+			element := child.GetElement()
+			if !containsString(componentTags, element.GetName()) {
+				continue
+			}
+			if qualifiedClassName(findAttribute(element, namespace, "name").GetValue(), packageName) == className {
+				matches = append(matches, elementMatch{element, elementPath("/manifest/application", element)})
+			}
+		}
+	}
+	return matches
+}
+
+// editComponents sets the attributes of the configured components. The class names are resolved against the
+// original package, so they still match when the package is changed at the same time.
+func editComponents(xmlNode *XmlNode, editor *manifestEditor, config *Config) error {
+	var missing []string
+	for _, c := range config.components {
+		matches := findComponents(xmlNode.GetElement(), editor.linker.packageName, c.name)
+		if len(matches) == 0 {
+			missing = append(missing, qualifiedClassName(c.name, editor.linker.packageName))
+			continue
+		}
+		for _, match := range matches {
+			for _, attr := range c.attrs {
+				oldValue, err := editor.setAttribute(match.element, attr.name, attr.value)
+				if err != nil {
+					return err
+				}
+				if oldValue == "" {
+					config.println("Setting", attr.name, "of", match.path, "to", attr.value)
+				} else {
+					config.println("Changing", attr.name, "of", match.path, "from", oldValue, "to", attr.value)
+				}
+			}
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("component(s) not found: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
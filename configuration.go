@@ -0,0 +1,410 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	densityAny  = 0xfffe
+	densityNone = 0xffff
+	// The Configuration stores mnc00 as this special value because 0 means "unset".
+	mncZero = 0xffff
+)
+
+var densities = map[string]uint32{
+	"ldpi":    120,
+	"mdpi":    160,
+	"tvdpi":   213,
+	"hdpi":    240,
+	"xhdpi":   320,
+	"xxhdpi":  480,
+	"xxxhdpi": 640,
+	"anydpi":  densityAny,
+	"nodpi":   densityNone,
+}
+
+// A qualifier handles one dimension of a Configuration.
+// parse returns the number of consumed parts or 0 if the parts don't start with this qualifier.
+// format returns "" if the dimension is unset.
+type qualifier struct {
+	parse  func(parts []string, config *Configuration) int
+	format func(config *Configuration) string
+}
+
+// qualifiers lists all dimensions in the order in which they must appear in a resource directory name.
+var qualifiers = []qualifier{
+	numberQualifier("mcc", "", func(c *Configuration) *uint32 { return &c.Mcc }),
+	{parseMnc, formatMnc},
+	{parseLocale, formatLocale},
+	enumQualifier(map[string]int32{
+		"ldltr": int32(Configuration_LAYOUT_DIRECTION_LTR),
+		"ldrtl": int32(Configuration_LAYOUT_DIRECTION_RTL),
+	}, func(c *Configuration) int32 { return int32(c.LayoutDirection) },
+		func(c *Configuration, v int32) { c.LayoutDirection = Configuration_LayoutDirection(v) }),
+	numberQualifier("sw", "dp", func(c *Configuration) *uint32 { return &c.SmallestScreenWidthDp }),
+	numberQualifier("w", "dp", func(c *Configuration) *uint32 { return &c.ScreenWidthDp }),
+	numberQualifier("h", "dp", func(c *Configuration) *uint32 { return &c.ScreenHeightDp }),
+	enumQualifier(map[string]int32{
+		"small":  int32(Configuration_SCREEN_LAYOUT_SIZE_SMALL),
+		"normal": int32(Configuration_SCREEN_LAYOUT_SIZE_NORMAL),
+		"large":  int32(Configuration_SCREEN_LAYOUT_SIZE_LARGE),
+		"xlarge": int32(Configuration_SCREEN_LAYOUT_SIZE_XLARGE),
+	}, func(c *Configuration) int32 { return int32(c.ScreenLayoutSize) },
+		func(c *Configuration, v int32) { c.ScreenLayoutSize = Configuration_ScreenLayoutSize(v) }),
+	enumQualifier(map[string]int32{
+		"long":    int32(Configuration_SCREEN_LAYOUT_LONG_LONG),
+		"notlong": int32(Configuration_SCREEN_LAYOUT_LONG_NOTLONG),
+	}, func(c *Configuration) int32 { return int32(c.ScreenLayoutLong) },
+		func(c *Configuration, v int32) { c.ScreenLayoutLong = Configuration_ScreenLayoutLong(v) }),
+	enumQualifier(map[string]int32{
+		"round":    int32(Configuration_SCREEN_ROUND_ROUND),
+		"notround": int32(Configuration_SCREEN_ROUND_NOTROUND),
+	}, func(c *Configuration) int32 { return int32(c.ScreenRound) },
+		func(c *Configuration, v int32) { c.ScreenRound = Configuration_ScreenRound(v) }),
+	enumQualifier(map[string]int32{
+		"widecg":   int32(Configuration_WIDE_COLOR_GAMUT_WIDECG),
+		"nowidecg": int32(Configuration_WIDE_COLOR_GAMUT_NOWIDECG),
+	}, func(c *Configuration) int32 { return int32(c.WideColorGamut) },
+		func(c *Configuration, v int32) { c.WideColorGamut = Configuration_WideColorGamut(v) }),
+	enumQualifier(map[string]int32{
+		"highdr": int32(Configuration_HDR_HIGHDR),
+		"lowdr":  int32(Configuration_HDR_LOWDR),
+	}, func(c *Configuration) int32 { return int32(c.Hdr) },
+		func(c *Configuration, v int32) { c.Hdr = Configuration_Hdr(v) }),
+	enumQualifier(map[string]int32{
+		"port":   int32(Configuration_ORIENTATION_PORT),
+		"land":   int32(Configuration_ORIENTATION_LAND),
+		"square": int32(Configuration_ORIENTATION_SQUARE),
+	}, func(c *Configuration) int32 { return int32(c.Orientation) },
+		func(c *Configuration, v int32) { c.Orientation = Configuration_Orientation(v) }),
+	enumQualifier(map[string]int32{
+		"desk":       int32(Configuration_UI_MODE_TYPE_DESK),
+		"car":        int32(Configuration_UI_MODE_TYPE_CAR),
+		"television": int32(Configuration_UI_MODE_TYPE_TELEVISION),
+		"appliance":  int32(Configuration_UI_MODE_TYPE_APPLIANCE),
+		"watch":      int32(Configuration_UI_MODE_TYPE_WATCH),
+		"vrheadset":  int32(Configuration_UI_MODE_TYPE_VRHEADSET),
+	}, func(c *Configuration) int32 { return int32(c.UiModeType) },
+		func(c *Configuration, v int32) { c.UiModeType = Configuration_UiModeType(v) }),
+	enumQualifier(map[string]int32{
+		"night":    int32(Configuration_UI_MODE_NIGHT_NIGHT),
+		"notnight": int32(Configuration_UI_MODE_NIGHT_NOTNIGHT),
+	}, func(c *Configuration) int32 { return int32(c.UiModeNight) },
+		func(c *Configuration, v int32) { c.UiModeNight = Configuration_UiModeNight(v) }),
+	{parseDensity, formatDensity},
+	enumQualifier(map[string]int32{
+		"notouch": int32(Configuration_TOUCHSCREEN_NOTOUCH),
+		"stylus":  int32(Configuration_TOUCHSCREEN_STYLUS),
+		"finger":  int32(Configuration_TOUCHSCREEN_FINGER),
+	}, func(c *Configuration) int32 { return int32(c.Touchscreen) },
+		func(c *Configuration, v int32) { c.Touchscreen = Configuration_Touchscreen(v) }),
+	enumQualifier(map[string]int32{
+		"keysexposed": int32(Configuration_KEYS_HIDDEN_KEYSEXPOSED),
+		"keyshidden":  int32(Configuration_KEYS_HIDDEN_KEYSHIDDEN),
+		"keyssoft":    int32(Configuration_KEYS_HIDDEN_KEYSSOFT),
+	}, func(c *Configuration) int32 { return int32(c.KeysHidden) },
+		func(c *Configuration, v int32) { c.KeysHidden = Configuration_KeysHidden(v) }),
+	enumQualifier(map[string]int32{
+		"nokeys": int32(Configuration_KEYBOARD_NOKEYS),
+		"qwerty": int32(Configuration_KEYBOARD_QWERTY),
+		"12key":  int32(Configuration_KEYBOARD_TWELVEKEY),
+	}, func(c *Configuration) int32 { return int32(c.Keyboard) },
+		func(c *Configuration, v int32) { c.Keyboard = Configuration_Keyboard(v) }),
+	enumQualifier(map[string]int32{
+		"navexposed": int32(Configuration_NAV_HIDDEN_NAVEXPOSED),
+		"navhidden":  int32(Configuration_NAV_HIDDEN_NAVHIDDEN),
+	}, func(c *Configuration) int32 { return int32(c.NavHidden) },
+		func(c *Configuration, v int32) { c.NavHidden = Configuration_NavHidden(v) }),
+	enumQualifier(map[string]int32{
+		"nonav":     int32(Configuration_NAVIGATION_NONAV),
+		"dpad":      int32(Configuration_NAVIGATION_DPAD),
+		"trackball": int32(Configuration_NAVIGATION_TRACKBALL),
+		"wheel":     int32(Configuration_NAVIGATION_WHEEL),
+	}, func(c *Configuration) int32 { return int32(c.Navigation) },
+		func(c *Configuration, v int32) { c.Navigation = Configuration_Navigation(v) }),
+	{parseScreenSize, formatScreenSize},
+	numberQualifier("v", "", func(c *Configuration) *uint32 { return &c.SdkVersion }),
+	{parseProduct, formatProduct},
+}
+
+// parseConfiguration parses Android resource qualifiers like "de-rDE-night-xxhdpi-v26" into a Configuration.
+// The qualifiers must be given in the same order as in resource directory names. An empty string or "default"
+// results in the default configuration.
+func parseConfiguration(s string) (*Configuration, error) {
+	config := &Configuration{}
+	if s == "" || s == "default" {
+		return config, nil
+	}
+	parts := strings.Split(s, "-")
+	for i, part := range parts {
+		// Qualifiers are case-insensitive, but product names aren't.
+		if lower := strings.ToLower(part); strings.HasPrefix(lower, "product=") {
+			parts[i] = "product=" + part[len("product="):]
+		} else {
+			parts[i] = lower
+		}
+	}
+	q := 0
+	for len(parts) > 0 {
+		consumed := 0
+		for ; q < len(qualifiers) && consumed == 0; q++ {
+			consumed = qualifiers[q].parse(parts, config)
+		}
+		if consumed == 0 {
+			return nil, fmt.Errorf("invalid configuration %q: unexpected qualifier %q", s, parts[0])
+		}
+		parts = parts[consumed:]
+	}
+	return config, nil
+}
+
+// formatConfiguration renders the Configuration as Android resource qualifiers like "de-rDE-night-xxhdpi-v26".
+// The default configuration is rendered as "".
+func formatConfiguration(config *Configuration) string {
+	var parts []string
+	for _, q := range qualifiers {
+		if part := q.format(config); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+func enumQualifier(names map[string]int32, get func(*Configuration) int32, set func(*Configuration, int32)) qualifier {
+	return qualifier{
+		parse: func(parts []string, config *Configuration) int {
+			if value, ok := names[parts[0]]; ok {
+				set(config, value)
+				return 1
+			}
+			return 0
+		},
+		format: func(config *Configuration) string {
+			value := get(config)
+			for name, v := range names {
+				if v == value {
+					return name
+				}
+			}
+			return ""
+		},
+	}
+}
+
+// numberQualifier handles qualifiers of the form <prefix><number><suffix> like "sw600dp" or "v26".
+func numberQualifier(prefix string, suffix string, field func(*Configuration) *uint32) qualifier {
+	return qualifier{
+		parse: func(parts []string, config *Configuration) int {
+			value, ok := parseNumber(parts[0], prefix, suffix)
+			if !ok || value == 0 {
+				return 0
+			}
+			*field(config) = value
+			return 1
+		},
+		format: func(config *Configuration) string {
+			if value := *field(config); value != 0 {
+				return fmt.Sprintf("%s%d%s", prefix, value, suffix)
+			}
+			return ""
+		},
+	}
+}
+
+func parseNumber(part string, prefix string, suffix string) (uint32, bool) {
+	if !strings.HasPrefix(part, prefix) || !strings.HasSuffix(part, suffix) || len(part) <= len(prefix)+len(suffix) {
+		return 0, false
+	}
+	digits := part[len(prefix) : len(part)-len(suffix)]
+	if !isDigits(digits) {
+		return 0, false
+	}
+	value, err := strconv.ParseUint(digits, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint32(value), true
+}
+
+func parseMnc(parts []string, config *Configuration) int {
+	value, ok := parseNumber(parts[0], "mnc", "")
+	if !ok {
+		return 0
+	}
+	if value == 0 {
+		value = mncZero
+	}
+	config.Mnc = value
+	return 1
+}
+
+func formatMnc(config *Configuration) string {
+	switch config.Mnc {
+	case 0:
+		return ""
+	case mncZero:
+		return "mnc00"
+	}
+	return fmt.Sprintf("mnc%02d", config.Mnc)
+}
+
+// parseLocale parses either the legacy "de" / "de-rDE" form or the BCP-47 form "b+sr+Latn+RS".
+// The Configuration stores the locale as a BCP-47 tag like "de-DE" or "sr-Latn-RS".
+func parseLocale(parts []string, config *Configuration) int {
+	part := parts[0]
+	if strings.HasPrefix(part, "b+") {
+		subtags := strings.Split(part[2:], "+")
+		for _, subtag := range subtags {
+			if subtag == "" || len(subtag) > 8 || !isAlphanumeric(subtag) {
+				return 0
+			}
+		}
+		if len(subtags[0]) < 2 || len(subtags[0]) > 3 || !isAlpha(subtags[0]) {
+			return 0
+		}
+		config.Locale = normalizeLocale(strings.Join(subtags, "-"))
+		return 1
+	}
+	// "car" is the only three-letter qualifier that could be mistaken for a language.
+	if len(part) < 2 || len(part) > 3 || !isAlpha(part) || part == "car" {
+		return 0
+	}
+	config.Locale = part
+	if len(parts) > 1 && strings.HasPrefix(parts[1], "r") && isRegion(parts[1][1:]) {
+		config.Locale += "-" + strings.ToUpper(parts[1][1:])
+		return 2
+	}
+	return 1
+}
+
+func formatLocale(config *Configuration) string {
+	if config.Locale == "" {
+		return ""
+	}
+	subtags := strings.Split(config.Locale, "-")
+	switch {
+	case len(subtags) == 1:
+		return subtags[0]
+	case len(subtags) == 2 && isRegion(subtags[1]):
+		return subtags[0] + "-r" + subtags[1]
+	}
+	return "b+" + strings.Join(subtags, "+")
+}
+
+// normalizeLocale brings the subtags of a BCP-47 tag into their canonical case (e.g. "sr-Latn-RS").
+func normalizeLocale(tag string) string {
+	subtags := strings.Split(tag, "-")
+	for i, subtag := range subtags {
+		switch {
+		case i == 0:
+			subtags[i] = strings.ToLower(subtag)
+		case len(subtag) == 4 && isAlpha(subtag):
+			subtags[i] = strings.ToUpper(subtag[:1]) + strings.ToLower(subtag[1:])
+		case isRegion(subtag):
+			subtags[i] = strings.ToUpper(subtag)
+		default:
+			subtags[i] = strings.ToLower(subtag)
+		}
+	}
+	return strings.Join(subtags, "-")
+}
+
+func parseDensity(parts []string, config *Configuration) int {
+	if density, ok := densities[parts[0]]; ok {
+		config.Density = density
+		return 1
+	}
+	if density, ok := parseNumber(parts[0], "", "dpi"); ok && density != 0 {
+		config.Density = density
+		return 1
+	}
+	return 0
+}
+
+func formatDensity(config *Configuration) string {
+	if config.Density == 0 {
+		return ""
+	}
+	for name, density := range densities {
+		if density == config.Density {
+			return name
+		}
+	}
+	return fmt.Sprintf("%ddpi", config.Density)
+}
+
+func parseScreenSize(parts []string, config *Configuration) int {
+	i := strings.Index(parts[0], "x")
+	if i <= 0 {
+		return 0
+	}
+	width, ok1 := parseNumber(parts[0][:i], "", "")
+	height, ok2 := parseNumber(parts[0][i+1:], "", "")
+	if !ok1 || !ok2 {
+		return 0
+	}
+	// The larger dimension always comes first.
+	if height > width {
+		width, height = height, width
+	}
+	config.ScreenWidth, config.ScreenHeight = width, height
+	return 1
+}
+
+func formatScreenSize(config *Configuration) string {
+	if config.ScreenWidth == 0 && config.ScreenHeight == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%dx%d", config.ScreenWidth, config.ScreenHeight)
+}
+
+// parseProduct parses the product of values like <string product="tablet">, which aren't in a qualified directory
+// but are distinct configs in the resource table. It's rendered as a final "product=tablet" part.
+func parseProduct(parts []string, config *Configuration) int {
+	if !strings.HasPrefix(parts[0], "product=") || len(parts[0]) == len("product=") {
+		return 0
+	}
+	config.Product = strings.TrimPrefix(parts[0], "product=")
+	return 1
+}
+
+func formatProduct(config *Configuration) string {
+	if config.Product == "" {
+		return ""
+	}
+	return "product=" + config.Product
+}
+
+// isRegion checks for a two-letter or three-digit region code.
+func isRegion(s string) bool {
+	return (len(s) == 2 && isAlpha(s)) || (len(s) == 3 && isDigits(s))
+}
+
+func isAlpha(s string) bool {
+	for _, c := range s {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func isAlphanumeric(s string) bool {
+	for _, c := range s {
+		if !isAlpha(string(c)) && !isDigits(string(c)) {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestConfigurationRoundTrip(t *testing.T) {
+	tests := []struct {
+		qualifiers string
+		want       *Configuration
+	}{
+		{"", &Configuration{}},
+		{"mcc310", &Configuration{Mcc: 310}},
+		{"mcc310-mnc04", &Configuration{Mcc: 310, Mnc: 4}},
+		{"mnc00", &Configuration{Mnc: mncZero}},
+		{"de", &Configuration{Locale: "de"}},
+		{"de-rDE", &Configuration{Locale: "de-DE"}},
+		{"es-r419", &Configuration{Locale: "es-419"}},
+		{"b+sr+Latn+RS", &Configuration{Locale: "sr-Latn-RS"}},
+		{"ldrtl", &Configuration{LayoutDirection: Configuration_LAYOUT_DIRECTION_RTL}},
+		{"ldltr", &Configuration{LayoutDirection: Configuration_LAYOUT_DIRECTION_LTR}},
+		{"sw600dp", &Configuration{SmallestScreenWidthDp: 600}},
+		{"w720dp", &Configuration{ScreenWidthDp: 720}},
+		{"h1024dp", &Configuration{ScreenHeightDp: 1024}},
+		{"xlarge", &Configuration{ScreenLayoutSize: Configuration_SCREEN_LAYOUT_SIZE_XLARGE}},
+		{"notlong", &Configuration{ScreenLayoutLong: Configuration_SCREEN_LAYOUT_LONG_NOTLONG}},
+		{"round", &Configuration{ScreenRound: Configuration_SCREEN_ROUND_ROUND}},
+		{"widecg", &Configuration{WideColorGamut: Configuration_WIDE_COLOR_GAMUT_WIDECG}},
+		{"lowdr", &Configuration{Hdr: Configuration_HDR_LOWDR}},
+		{"land", &Configuration{Orientation: Configuration_ORIENTATION_LAND}},
+		{"car", &Configuration{UiModeType: Configuration_UI_MODE_TYPE_CAR}},
+		{"vrheadset", &Configuration{UiModeType: Configuration_UI_MODE_TYPE_VRHEADSET}},
+		{"night", &Configuration{UiModeNight: Configuration_UI_MODE_NIGHT_NIGHT}},
+		{"xxhdpi", &Configuration{Density: 480}},
+		{"420dpi", &Configuration{Density: 420}},
+		{"anydpi", &Configuration{Density: densityAny}},
+		{"nodpi", &Configuration{Density: densityNone}},
+		{"finger", &Configuration{Touchscreen: Configuration_TOUCHSCREEN_FINGER}},
+		{"keyssoft", &Configuration{KeysHidden: Configuration_KEYS_HIDDEN_KEYSSOFT}},
+		{"12key", &Configuration{Keyboard: Configuration_KEYBOARD_TWELVEKEY}},
+		{"navhidden", &Configuration{NavHidden: Configuration_NAV_HIDDEN_NAVHIDDEN}},
+		{"dpad", &Configuration{Navigation: Configuration_NAVIGATION_DPAD}},
+		{"1920x1080", &Configuration{ScreenWidth: 1920, ScreenHeight: 1080}},
+		{"v26", &Configuration{SdkVersion: 26}},
+		{"product=tablet", &Configuration{Product: "tablet"}},
+		{"product=Tablet", &Configuration{Product: "Tablet"}},
+		{"de-rDE-night-xxhdpi-v26", &Configuration{Locale: "de-DE", UiModeNight: Configuration_UI_MODE_NIGHT_NIGHT, Density: 480, SdkVersion: 26}},
+		{"mcc262-en-rGB-ldltr-sw360dp-land-television-notouch-v21-product=tv", &Configuration{
+			Mcc: 262, Locale: "en-GB", LayoutDirection: Configuration_LAYOUT_DIRECTION_LTR, SmallestScreenWidthDp: 360,
+			Orientation: Configuration_ORIENTATION_LAND, UiModeType: Configuration_UI_MODE_TYPE_TELEVISION,
+			Touchscreen: Configuration_TOUCHSCREEN_NOTOUCH, SdkVersion: 21, Product: "tv",
+		}},
+	}
+	for _, test := range tests {
+		config, err := parseConfiguration(test.qualifiers)
+		if err != nil {
+			t.Errorf("parseConfiguration(%q) failed: %v", test.qualifiers, err)
+			continue
+		}
+		if !proto.Equal(config, test.want) {
+			t.Errorf("parseConfiguration(%q) = %v, want %v", test.qualifiers, config, test.want)
+		}
+		if got := formatConfiguration(config); got != test.qualifiers {
+			t.Errorf("formatConfiguration(parseConfiguration(%q)) = %q", test.qualifiers, got)
+		}
+	}
+}
+
+func TestParseConfigurationNormalizes(t *testing.T) {
+	tests := []struct {
+		qualifiers string
+		want       string
+	}{
+		{"default", ""},
+		{"DE-rde", "de-rDE"},
+		{"b+SR+latn", "b+sr+Latn"},
+		{"1080x1920", "1920x1080"},
+		{"XXHDPI-Product=Tablet", "xxhdpi-product=Tablet"},
+	}
+	for _, test := range tests {
+		config, err := parseConfiguration(test.qualifiers)
+		if err != nil {
+			t.Errorf("parseConfiguration(%q) failed: %v", test.qualifiers, err)
+			continue
+		}
+		if got := formatConfiguration(config); got != test.want {
+			t.Errorf("formatConfiguration(parseConfiguration(%q)) = %q, want %q", test.qualifiers, got, test.want)
+		}
+	}
+}
+
+func TestParseConfigurationInvalid(t *testing.T) {
+	for _, qualifiers := range []string{
+		"v26-xxhdpi",
+		"night-de",
+		"xxhdpi-land",
+		"mnc01-mcc310",
+		"product=tv-v21",
+		"ldrtl-de",
+		"sw0dp",
+		"xxhdpi-xxhdpi",
+		"fooo",
+		"de-",
+		"product=",
+	} {
+		if config, err := parseConfiguration(qualifiers); err == nil {
+			t.Errorf("parseConfiguration(%q) = %v, want an error", qualifiers, config)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// editDebuggable makes the app debuggable and/or profileable (via <profileable android:shell="true">) for
+// troubleshooting release builds.
+func editDebuggable(xmlNode *XmlNode, editor *manifestEditor, config *Config) error {
+	if !config.makeDebuggable && !config.profileable {
+		return nil
+	}
+	root := xmlNode.GetElement()
+	applications := childElements(root, "application")
+	if len(applications) == 0 {
+		// Config splits without code don't need the flags, only the base APK does.
+		if getManifestAttribute(xmlNode, "", "split") != nil {
+			return nil
+		}
+		config.println("Adding <application>")
+		applications = append(applications, addElement(root, "application", -1))
+	}
+	application := applications[0]
+
+	if config.makeDebuggable {
+		oldValue, err := editor.setAttribute(application, "debuggable", "true")
+		if err != nil {
+			return err
+		}
+		if oldValue != "true" {
+			config.println("Setting debuggable to true")
+		}
+	}
+	if config.profileable {
+		var profileable *XmlElement
+		if existing := childElements(application, "profileable"); len(existing) > 0 {
+			profileable = existing[0]
+		} else {
+			config.println("Adding <profileable android:shell=\"true\">")
+			profileable = addElement(application, "profileable", -1)
+		}
+		oldValue, err := editor.setAttribute(profileable, "shell", "true")
+		if err != nil {
+			return err
+		}
+		if oldValue != "" && oldValue != "true" {
+			config.println("Changing <profileable> shell from", oldValue, "to true")
+		}
+		// android:enabled="false" disables profiling altogether.
+		if removeAttribute(profileable, "enabled") {
+			config.println("Removing <profileable> enabled")
+		}
+	}
+	return nil
+}
+
+// signingConfig holds the apksigner options for re-signing modified APKs. Without keystore, the debug keystore is
+// used. The passwords are given in apksigner's format, e.g. pass:android, env:KEYSTORE_PASSWORD or file:password.txt.
+type signingConfig struct {
+	keystore     string
+	keystorePass string
+	keyAlias     string
+	keyPass      string
+}
+
+// debugKeystore returns the path of the Android SDK's debug keystore, which is used if no keystore is given.
+func debugKeystore() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(home, ".android", "debug.keystore")
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("no keystore given (--ks) and the debug keystore can't be used: %w", err)
+	}
+	return path, nil
+}
+
+// signApk zipaligns and signs the APK in place, so it can be installed right away.
+func signApk(path string, signing *signingConfig, config *Config) error {
+	keystore, keystorePass := signing.keystore, signing.keystorePass
+	if keystore == "" {
+		var err error
+		if keystore, err = debugKeystore(); err != nil {
+			return err
+		}
+		// The debug keystore's well-known password, which mustn't be tried on other keystores.
+		if keystorePass == "" {
+			keystorePass = "pass:android"
+		}
+	}
+	config.println("Signing with", keystore)
+	aligned := path + ".aligned"
+	defer os.Remove(aligned)
+	out, err := exec.Command("zipalign", "-f", "-p", "4", path, aligned).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed executing zipalign: %v %s", err, out)
+	}
+	args := []string{"sign", "--ks", keystore, "--ks-pass", keystorePass}
+	if signing.keyAlias != "" {
+		args = append(args, "--ks-key-alias", signing.keyAlias)
+	}
+	if signing.keyPass != "" {
+		args = append(args, "--key-pass", signing.keyPass)
+	}
+	args = append(args, "--out", path, aligned)
+	out, err = exec.Command("apksigner", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed executing apksigner: %v %s", err, out)
+	}
+	return nil
+}
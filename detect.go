@@ -0,0 +1,88 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// inputType is the kind of file to modify.
+type inputType int
+
+const (
+	// inputTypeAuto means that the type is detected from the file contents.
+	inputTypeAuto inputType = iota - 1
+	inputTypeManifest
+	inputTypeApk
+	inputTypeApks
+	inputTypeAab
+	inputTypeAar
+)
+
+var inputTypeNames = map[string]inputType{
+	"manifest": inputTypeManifest,
+	"apk":      inputTypeApk,
+	"apks":     inputTypeApks,
+	"aab":      inputTypeAab,
+	"aar":      inputTypeAar,
+}
+
+func (t inputType) String() string {
+	for name, value := range inputTypeNames {
+		if value == t {
+			return name
+		}
+	}
+	return "unknown"
+}
+
+func parseInputType(name string) (inputType, error) {
+	if t, ok := inputTypeNames[strings.ToLower(name)]; ok {
+		return t, nil
+	}
+	return 0, fmt.Errorf("unknown type %q (expected apk, apks, aab, aar or manifest)", name)
+}
+
+var zipMagic = []byte("PK\x03\x04")
+
+// detectInputType looks at the file contents instead of the file extension, so renamed artifacts like
+// app-release.zip are handled correctly. Files which aren't zip archives are treated as standalone manifests.
+func detectInputType(r io.ReaderAt, size int64) (inputType, error) {
+	magic := make([]byte, len(zipMagic))
+	if _, err := r.ReadAt(magic, 0); err != nil || !bytes.Equal(magic, zipMagic) {
+		return inputTypeManifest, nil
+	}
+
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return 0, err
+	}
+	if findFile(zr, apksTocPath) != nil {
+		return inputTypeApks, nil
+	}
+	if findFile(zr, "base/manifest/AndroidManifest.xml") != nil || findFile(zr, bundleConfigPath) != nil {
+		return inputTypeAab, nil
+	}
+	if findFile(zr, "resources.arsc") != nil {
+		return inputTypeApk, nil
+	}
+	manifest := findFile(zr, "AndroidManifest.xml")
+	if manifest == nil {
+		return 0, errors.New("neither an APK, APK set, AAB nor AAR")
+	}
+	// APKs without resources still have a compiled manifest while AARs have a plain text one.
+	rc, err := manifest.Open()
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+	header := make([]byte, 64)
+	n, _ := io.ReadFull(rc, header)
+	if detectXmlFormat(header[:n]) == xmlFormatText {
+		return inputTypeAar, nil
+	}
+	return inputTypeApk, nil
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// buildConfigFields are the BuildConfig constants which are relevant for the app's version.
+var buildConfigFields = []string{"APPLICATION_ID", "VERSION_CODE", "VERSION_NAME"}
+
+// buildConfig holds the version constants of a BuildConfig class.
+type buildConfig struct {
+	className string
+	fields    map[string]string
+}
+
+// dexReader reads the static field values of classes from a dex file. Reads outside of the data set err instead of
+// panicking, so the caller only has to check err at the end.
+type dexReader struct {
+	data []byte
+	err  error
+}
+
+func (d *dexReader) bytes(off int, n int) []byte {
+	if n < 0 {
+		n = 0
+	}
+	if d.err != nil || off < 0 || off+n > len(d.data) {
+		if d.err == nil {
+			d.err = errors.New("unexpected end of dex file")
+		}
+		return make([]byte, n)
+	}
+	return d.data[off : off+n]
+}
+
+func (d *dexReader) u32(off int) int {
+	return int(binary.LittleEndian.Uint32(d.bytes(off, 4)))
+}
+
+// uleb128 reads an unsigned LEB128 value and advances off.
+func (d *dexReader) uleb128(off *int) int {
+	result := 0
+	for shift := 0; shift < 35; shift += 7 {
+		b := d.bytes(*off, 1)[0]
+		*off++
+		result |= int(b&0x7f) << shift
+		if b&0x80 == 0 {
+			break
+		}
+	}
+	return result
+}
+
+func (d *dexReader) stringAt(idx int) string {
+	off := d.u32(d.u32(0x3c) + 4*idx)
+	d.uleb128(&off) // The UTF-16 length.
+	end := off
+	for end < len(d.data) && d.data[end] != 0 {
+		end++
+	}
+	return string(d.bytes(off, end-off))
+}
+
+func (d *dexReader) typeDescriptor(idx int) string {
+	return d.stringAt(d.u32(d.u32(0x44) + 4*idx))
+}
+
+func (d *dexReader) fieldName(idx int) string {
+	return d.stringAt(d.u32(d.u32(0x54) + 8*idx + 4))
+}
+
+// encodedValue reads an encoded_value and advances off. Only numbers, strings, booleans and null are formatted, all
+// other values are skipped and returned as "".
+func (d *dexReader) encodedValue(off *int) string {
+	header := d.bytes(*off, 1)[0]
+	*off++
+	valueType, arg := header&0x1f, int(header>>5)
+	switch valueType {
+	case 0x1c: // array
+		d.encodedArray(off, func(int, string) {})
+		return ""
+	case 0x1d: // annotation
+		d.uleb128(off)
+		for size := d.uleb128(off); size > 0 && d.err == nil; size-- {
+			d.uleb128(off)
+			d.encodedValue(off)
+		}
+		return ""
+	case 0x1e:
+		return "null"
+	case 0x1f:
+		return strconv.FormatBool(arg != 0)
+	}
+	raw := d.bytes(*off, arg+1)
+	*off += arg + 1
+	var value uint64
+	for i := len(raw) - 1; i >= 0; i-- {
+		value = value<<8 | uint64(raw[i])
+	}
+	switch valueType {
+	case 0x00, 0x02, 0x04, 0x06: // byte, short, int, long
+		shift := uint(64 - 8*len(raw))
+		return strconv.FormatInt(int64(value<<shift)>>shift, 10)
+	case 0x03: // char
+		return strconv.FormatUint(value, 10)
+	case 0x17: // string
+		return d.stringAt(int(value))
+	}
+	return ""
+}
+
+func (d *dexReader) encodedArray(off *int, f func(i int, value string)) {
+	size := d.uleb128(off)
+	for i := 0; i < size && d.err == nil; i++ {
+		f(i, d.encodedValue(off))
+	}
+}
+
+// readBuildConfigs returns the version constants of all BuildConfig classes in the dex file. R8 usually inlines and
+// removes these classes in release builds, so often there are none.
+func readBuildConfigs(data []byte) ([]buildConfig, error) {
+	if len(data) < 0x70 || !strings.HasPrefix(string(data), "dex\n") {
+		return nil, errors.New("not a dex file")
+	}
+	d := &dexReader{data: data}
+	var configs []buildConfig
+	classDefsOff := d.u32(0x64)
+	for i := 0; i < d.u32(0x60) && d.err == nil; i++ {
+		classDef := classDefsOff + 32*i
+		descriptor := d.typeDescriptor(d.u32(classDef))
+		if !strings.HasSuffix(descriptor, "/BuildConfig;") {
+			continue
+		}
+		classDataOff, staticValuesOff := d.u32(classDef+24), d.u32(classDef+28)
+		if classDataOff == 0 || staticValuesOff == 0 {
+			continue
+		}
+
+		// The static fields are listed with their field index deltas, in the order of the static values.
+		off := classDataOff
+		staticFields := d.uleb128(&off)
+		for j := 0; j < 3; j++ {
+			d.uleb128(&off)
+		}
+		var fieldIdxs []int
+		fieldIdx := 0
+		for j := 0; j < staticFields && d.err == nil; j++ {
+			fieldIdx += d.uleb128(&off)
+			d.uleb128(&off) // The access flags.
+			fieldIdxs = append(fieldIdxs, fieldIdx)
+		}
+
+		config := buildConfig{
+			className: strings.ReplaceAll(strings.TrimSuffix(strings.TrimPrefix(descriptor, "L"), ";"), "/", "."),
+			fields:    map[string]string{},
+		}
+		off = staticValuesOff
+		d.encodedArray(&off, func(i int, value string) {
+			if i >= len(fieldIdxs) {
+				return
+			}
+			if name := d.fieldName(fieldIdxs[i]); containsString(buildConfigFields, name) {
+				config.fields[name] = value
+			}
+		})
+		if len(config.fields) > 0 {
+			configs = append(configs, config)
+		}
+	}
+	if d.err != nil {
+		return nil, fmt.Errorf("failed to parse dex file: %w", d.err)
+	}
+	return configs, nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestReadBuildConfigs(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/classes.dex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	configs, err := readBuildConfigs(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []buildConfig{{
+		className: "com.example.app.BuildConfig",
+		fields: map[string]string{
+			"APPLICATION_ID": "com.other",
+			"VERSION_CODE":   "300",
+			"VERSION_NAME":   "0.9",
+		},
+	}}
+	if !reflect.DeepEqual(configs, want) {
+		t.Errorf("readBuildConfigs() = %v, want %v", configs, want)
+	}
+
+	// Truncated files must not panic. Whether they fail depends on which parts are cut off.
+	for size := 0x70; size < len(data); size++ {
+		readBuildConfigs(data[:size])
+	}
+	if _, err := readBuildConfigs([]byte("PK\x03\x04")); err == nil {
+		t.Error("readBuildConfigs() of a zip succeeded, want an error")
+	}
+}
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// knownFeatures are the features defined by the platform (PackageManager.FEATURE_*). Adding a feature in the android.
+// namespace which isn't one of them prints a warning, which catches typos that would otherwise silently filter the app
+// on Google Play. Newer platforms may define features which are missing here, so it's not an error.
+var knownFeatures = map[string]bool{
+	"android.hardware.audio.low_latency":                        true,
+	"android.hardware.audio.output":                             true,
+	"android.hardware.audio.pro":                                true,
+	"android.hardware.biometrics.face":                          true,
+	"android.hardware.biometrics.iris":                          true,
+	"android.hardware.bluetooth":                                true,
+	"android.hardware.bluetooth_le":                             true,
+	"android.hardware.camera":                                   true,
+	"android.hardware.camera.any":                               true,
+	"android.hardware.camera.ar":                                true,
+	"android.hardware.camera.autofocus":                         true,
+	"android.hardware.camera.capability.manual_post_processing": true,
+	"android.hardware.camera.capability.manual_sensor":          true,
+	"android.hardware.camera.capability.raw":                    true,
+	"android.hardware.camera.concurrent":                        true,
+	"android.hardware.camera.external":                          true,
+	"android.hardware.camera.flash":                             true,
+	"android.hardware.camera.front":                             true,
+	"android.hardware.camera.level.full":                        true,
+	"android.hardware.consumerir":                               true,
+	"android.hardware.faketouch":                                true,
+	"android.hardware.faketouch.multitouch.distinct":            true,
+	"android.hardware.faketouch.multitouch.jazzhand":            true,
+	"android.hardware.fingerprint":                              true,
+	"android.hardware.gamepad":                                  true,
+	"android.hardware.hardware_keystore":                        true,
+	"android.hardware.identity_credential":                      true,
+	"android.hardware.location":                                 true,
+	"android.hardware.location.gps":                             true,
+	"android.hardware.location.network":                         true,
+	"android.hardware.microphone":                               true,
+	"android.hardware.nfc":                                      true,
+	"android.hardware.nfc.hce":                                  true,
+	"android.hardware.nfc.hcef":                                 true,
+	"android.hardware.opengles.aep":                             true,
+	"android.hardware.ram.low":                                  true,
+	"android.hardware.ram.normal":                               true,
+	"android.hardware.screen.landscape":                         true,
+	"android.hardware.screen.portrait":                          true,
+	"android.hardware.security.model.compatible":                true,
+	"android.hardware.sensor.accelerometer":                     true,
+	"android.hardware.sensor.ambient_temperature":               true,
+	"android.hardware.sensor.barometer":                         true,
+	"android.hardware.sensor.compass":                           true,
+	"android.hardware.sensor.gyroscope":                         true,
+	"android.hardware.sensor.heartrate":                         true,
+	"android.hardware.sensor.heartrate.ecg":                     true,
+	"android.hardware.sensor.hifi_sensors":                      true,
+	"android.hardware.sensor.hinge_angle":                       true,
+	"android.hardware.sensor.light":                             true,
+	"android.hardware.sensor.proximity":                         true,
+	"android.hardware.sensor.relative_humidity":                 true,
+	"android.hardware.sensor.stepcounter":                       true,
+	"android.hardware.sensor.stepdetector":                      true,
+	"android.hardware.strongbox_keystore":                       true,
+	"android.hardware.telephony":                                true,
+	"android.hardware.telephony.cdma":                           true,
+	"android.hardware.telephony.euicc":                          true,
+	"android.hardware.telephony.gsm":                            true,
+	"android.hardware.telephony.ims":                            true,
+	"android.hardware.telephony.mbms":                           true,
+	"android.hardware.touchscreen":                              true,
+	"android.hardware.touchscreen.multitouch":                   true,
+	"android.hardware.touchscreen.multitouch.distinct":          true,
+	"android.hardware.touchscreen.multitouch.jazzhand":          true,
+	"android.hardware.type.automotive":                          true,
+	"android.hardware.type.embedded":                            true,
+	"android.hardware.type.pc":                                  true,
+	"android.hardware.type.television":                          true,
+	"android.hardware.type.watch":                               true,
+	"android.hardware.usb.accessory":                            true,
+	"android.hardware.usb.host":                                 true,
+	"android.hardware.uwb":                                      true,
+	"android.hardware.vr.high_performance":                      true,
+	"android.hardware.vulkan.compute":                           true,
+	"android.hardware.vulkan.level":                             true,
+	"android.hardware.vulkan.version":                           true,
+	"android.hardware.wifi":                                     true,
+	"android.hardware.wifi.aware":                               true,
+	"android.hardware.wifi.direct":                              true,
+	"android.hardware.wifi.passpoint":                           true,
+	"android.hardware.wifi.rtt":                                 true,
+	"android.software.activities_on_secondary_displays":         true,
+	"android.software.app_widgets":                              true,
+	"android.software.autofill":                                 true,
+	"android.software.backup":                                   true,
+	"android.software.cant_save_state":                          true,
+	"android.software.companion_device_setup":                   true,
+	"android.software.connectionservice":                        true,
+	"android.software.credentials":                              true,
+	"android.software.device_admin":                             true,
+	"android.software.freeform_window_management":               true,
+	"android.software.home_screen":                              true,
+	"android.software.input_methods":                            true,
+	"android.software.ipsec_tunnels":                            true,
+	"android.software.leanback":                                 true,
+	"android.software.leanback_only":                            true,
+	"android.software.live_tv":                                  true,
+	"android.software.live_wallpaper":                           true,
+	"android.software.managed_users":                            true,
+	"android.software.midi":                                     true,
+	"android.software.picture_in_picture":                       true,
+	"android.software.print":                                    true,
+	"android.software.securely_removes_users":                   true,
+	"android.software.sip":                                      true,
+	"android.software.sip.voip":                                 true,
+	"android.software.verified_boot":                            true,
+	"android.software.vr.mode":                                  true,
+	"android.software.webview":                                  true,
+}
+
+// supportsScreensAttrs are the attributes of <supports-screens>.
+var supportsScreensAttrs = []string{
+	"resizeable", "smallScreens", "normalScreens", "largeScreens", "xlargeScreens", "anyDensity",
+	"requiresSmallestWidthDp", "compatibleWidthLimitDp", "largestWidthLimitDp",
+}
+
+// featureSpec is a feature given as name[,required=false] or glEsVersion=3.1[,required=true].
+type featureSpec struct {
+	name        string
+	glEsVersion string
+	attrs       []attributeSpec
+}
+
+func parseFeatureSpec(spec string, withAttrs bool) (featureSpec, error) {
+	parts := strings.Split(spec, ",")
+	f := featureSpec{name: strings.TrimSpace(parts[0])}
+	if strings.HasPrefix(f.name, "glEsVersion=") {
+		version, err := parseGlEsVersion(strings.TrimPrefix(f.name, "glEsVersion="))
+		if err != nil {
+			return f, err
+		}
+		f.name, f.glEsVersion = "", version
+	} else if f.name == "" {
+		return f, fmt.Errorf("missing feature name")
+	}
+	if len(parts) > 1 && !withAttrs {
+		return f, fmt.Errorf("invalid feature %q: attributes are not supported here", spec)
+	}
+	for _, part := range parts[1:] {
+		attr, err := parseAttributeSpec(part)
+		if err != nil {
+			return f, fmt.Errorf("invalid feature %q: %w", spec, err)
+		}
+		f.attrs = append(f.attrs, attr)
+	}
+	return f, nil
+}
+
+// checkFeatureName warns about features in the android. namespace which the platform doesn't define. It's only
+// called for features which are added, so existing features can always be removed or toggled.
+func checkFeatureName(f featureSpec, config *Config) {
+	if strings.HasPrefix(f.name, "android.") && !knownFeatures[f.name] {
+		config.println("Warning: unknown feature", f.name)
+	}
+}
+
+// parseGlEsVersion accepts versions like 3.1 or the manifest's hex format like 0x00030001.
+func parseGlEsVersion(value string) (string, error) {
+	if strings.HasPrefix(value, "0x") {
+		if _, err := strconv.ParseUint(value[2:], 16, 32); err != nil {
+			return "", fmt.Errorf("invalid glEsVersion %q", value)
+		}
+		return value, nil
+	}
+	parts := strings.Split(value, ".")
+	major, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil || len(parts) > 2 {
+		return "", fmt.Errorf("invalid glEsVersion %q (expected e.g. 3.1 or 0x00030001)", value)
+	}
+	var minor uint64
+	if len(parts) == 2 {
+		if minor, err = strconv.ParseUint(parts[1], 10, 16); err != nil {
+			return "", fmt.Errorf("invalid glEsVersion %q (expected e.g. 3.1 or 0x00030001)", value)
+		}
+	}
+	return fmt.Sprintf("0x%08x", major<<16|minor), nil
+}
+
+func (f featureSpec) String() string {
+	switch f.glEsVersion {
+	case "":
+		return f.name
+	case "*":
+		return "glEsVersion"
+	}
+	return "glEsVersion=" + f.glEsVersion
+}
+
+// matches reports whether the <uses-feature> element declares this feature. All glEsVersion features match each
+// other because an app can only require one OpenGL ES version.
+func (f featureSpec) matches(element *XmlElement) bool {
+	if f.glEsVersion != "" {
+		return findAttribute(element, namespace, "glEsVersion") != nil
+	}
+	return findAttribute(element, namespace, "name").GetValue() == f.name
+}
+
+// editFeatures removes, adds and toggles <uses-feature> elements and sets the <supports-screens> attributes.
+func editFeatures(xmlNode *XmlNode, editor *manifestEditor, config *Config) error {
+	root := xmlNode.GetElement()
+	for _, f := range config.removeFeatures {
+		removed := false
+		children := root.Child[:0]
+		for _, child := range root.Child {
+			if child.GetElement().GetName() == "uses-feature" && f.matches(child.GetElement()) {
+				config.println("Removing <uses-feature>", f)
+				removed = true
+				continue
+			}
+			children = append(children, child)
+		}
+		root.Child = children
+		if !removed {
+			config.println("Warning: feature", f, "not found")
+		}
+	}
+
+	for _, f := range config.addFeatures {
+		var element *XmlElement
+		for _, existing := range childElements(root, "uses-feature") {
+			if f.matches(existing) {
+				element = existing
+			}
+		}
+		if element == nil {
+			checkFeatureName(f, config)
+			config.println("Adding <uses-feature>", f)
+			element = addElement(root, "uses-feature", insertionIndex(root, "uses-feature"))
+		} else {
+			config.println("Updating <uses-feature>", f)
+		}
+		attrs := f.attrs
+		if f.glEsVersion != "" {
+			attrs = append([]attributeSpec{{"glEsVersion", f.glEsVersion}}, attrs...)
+		} else {
+			attrs = append([]attributeSpec{{"name", f.name}}, attrs...)
+		}
+		for _, attr := range attrs {
+			if _, err := editor.setAttribute(element, attr.name, attr.value); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, toggle := range config.featureRequired {
+		found := false
+		for _, element := range childElements(root, "uses-feature") {
+			if !toggle.feature.matches(element) {
+				continue
+			}
+			found = true
+			oldValue, err := editor.setAttribute(element, "required", toggle.value)
+			if err != nil {
+				return err
+			}
+			if oldValue == "" {
+				// A missing required attribute means true.
+				oldValue = "true"
+			}
+			config.println("Changing required of", toggle.feature, "from", oldValue, "to", toggle.value)
+		}
+		if !found {
+			config.println("Warning: feature", toggle.feature, "not found")
+		}
+	}
+
+	if len(config.supportsScreens) > 0 {
+		var element *XmlElement
+		if elements := childElements(root, "supports-screens"); len(elements) > 0 {
+			element = elements[0]
+		} else {
+			config.println("Adding <supports-screens>")
+			element = addElement(root, "supports-screens", insertionIndex(root, "supports-screens"))
+		}
+		for _, attr := range config.supportsScreens {
+			oldValue, err := editor.setAttribute(element, attr.name, attr.value)
+			if err != nil {
+				return err
+			}
+			if oldValue == "" {
+				config.println("Setting supports-screens", attr.name, "to", attr.value)
+			} else {
+				config.println("Changing supports-screens", attr.name, "from", oldValue, "to", attr.value)
+			}
+		}
+	}
+	return nil
+}
+
+// featureToggle sets the required attribute of a feature.
+type featureToggle struct {
+	feature featureSpec
+	value   string
+}
+
+func parseFeatureToggle(spec string) (featureToggle, error) {
+	i := strings.LastIndexByte(spec, '=')
+	if i <= 0 {
+		return featureToggle{}, fmt.Errorf("invalid feature toggle %q (expected name=true|false)", spec)
+	}
+	value := strings.TrimSpace(spec[i+1:])
+	if value != "true" && value != "false" {
+		return featureToggle{}, fmt.Errorf("invalid feature toggle %q (expected name=true|false)", spec)
+	}
+	name := strings.TrimSpace(spec[:i])
+	feature := featureSpec{name: name}
+	if name == "glEsVersion" {
+		// Matches the uses-feature with any OpenGL ES version.
+		feature = featureSpec{glEsVersion: "*"}
+	} else if name == "" {
+		return featureToggle{}, fmt.Errorf("missing feature name")
+	}
+	return featureToggle{feature, value}, nil
+}
+
+func parseSupportsScreens(spec string) ([]attributeSpec, error) {
+	var attrs []attributeSpec
+	for _, part := range strings.Split(spec, ",") {
+		attr, err := parseAttributeSpec(part)
+		if err != nil {
+			return nil, err
+		}
+		if !containsString(supportsScreensAttrs, attr.name) {
+			return nil, fmt.Errorf("unknown supports-screens attribute %s (expected %s)", attr.name, strings.Join(supportsScreensAttrs, ", "))
+		}
+		attrs = append(attrs, attr)
+	}
+	return attrs, nil
+}
@@ -2,4 +2,7 @@ module github.com/ensody/androidmanifest-changer
 
 go 1.17
 
-require google.golang.org/protobuf v1.33.0
+require (
+	google.golang.org/protobuf v1.33.0
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"archive/zip"
+	"fmt"
+	"strings"
+)
+
+// debugAttrs are the <application> attributes which must not end up in release builds. Both default to false.
+var debugAttrs = []string{"debuggable", "testOnly"}
+
+// hardeningIssues returns the flags of the manifest which --harden would change, e.g. android:debuggable=true.
+func hardeningIssues(manifest *XmlNode, libs nativeLibs, config *Config) []string {
+	var issues []string
+	for _, application := range childElements(manifest.GetElement(), "application") {
+		for _, name := range debugAttrs {
+			if value, ok := applicationFlag(application, name); ok && value != "false" {
+				issues = append(issues, "android:"+name+"="+value)
+			}
+		}
+		if value, ok := applicationFlag(application, "extractNativeLibs"); ok && value == "false" && libs.compressed {
+			issues = append(issues, "android:extractNativeLibs=false with compressed native libraries")
+		}
+		if config.disallowBackup {
+			if value, ok := applicationFlag(application, "allowBackup"); !ok || value != "false" {
+				if !ok {
+					value = "true (default)"
+				}
+				issues = append(issues, "android:allowBackup="+value)
+			}
+		}
+	}
+	return issues
+}
+
+func applicationFlag(application *XmlElement, name string) (string, bool) {
+	attr := findAttribute(application, namespace, name)
+	if attr == nil {
+		return "", false
+	}
+	return attributeText(attr), true
+}
+
+// editHardening removes debug flags from the <application> for release builds:
+//
+//   - debuggable and testOnly are removed.
+//   - extractNativeLibs=false is removed if the APK's native libraries are compressed, which would make the
+//     installation fail.
+//   - allowBackup is set to false if config.disallowBackup is set.
+func editHardening(xmlNode *XmlNode, editor *manifestEditor, config *Config) error {
+	if !config.harden && !config.disallowBackup {
+		return nil
+	}
+	for _, application := range childElements(xmlNode.GetElement(), "application") {
+		if config.harden {
+			for _, name := range debugAttrs {
+				if value, ok := applicationFlag(application, name); ok {
+					config.println("Removing", name+"="+value)
+					removeAttribute(application, name)
+				}
+			}
+			if value, ok := applicationFlag(application, "extractNativeLibs"); ok && value == "false" && editor.nativeLibs.compressed {
+				config.println("Removing extractNativeLibs=false because the native libraries are compressed")
+				removeAttribute(application, "extractNativeLibs")
+			}
+		}
+		if config.disallowBackup {
+			oldValue, err := editor.setAttribute(application, "allowBackup", "false")
+			if err != nil {
+				return err
+			}
+			if oldValue != "false" {
+				config.println("Setting allowBackup to false")
+			}
+		}
+	}
+	return nil
+}
+
+// nativeLibs describes the native libraries of the APK which a manifest belongs to.
+type nativeLibs struct {
+	// abis are the lib/ directories, e.g. arm64-v8a.
+	abis []string
+	// compressed is set if any library is compressed. These can't be loaded directly from the APK, so they need
+	// android:extractNativeLibs.
+	compressed bool
+}
+
+func readNativeLibs(a *archive) nativeLibs {
+	var libs nativeLibs
+	for _, f := range a.reader.File {
+		parts := strings.Split(f.Name, "/")
+		if len(parts) != 3 || parts[0] != "lib" || !strings.HasSuffix(f.Name, ".so") {
+			continue
+		}
+		if !containsString(libs.abis, parts[1]) {
+			libs.abis = append(libs.abis, parts[1])
+		}
+		if f.Method != zip.Store {
+			libs.compressed = true
+		}
+	}
+	return libs
+}
+
+// checkHardened prints the debug flags of the files, like --harden would report them, and returns the number of
+// files which aren't hardened.
+func checkHardened(paths []string, fileType inputType, config *Config) (int, error) {
+	failed := 0
+	for _, path := range paths {
+		manifest, libs, err := readHardeningInput(path, fileType)
+		if err != nil {
+			return failed, fmt.Errorf("%s: %w", path, err)
+		}
+		issues := hardeningIssues(manifest, libs, config)
+		if len(issues) == 0 {
+			fmt.Printf("%s: OK\n", path)
+			continue
+		}
+		failed++
+		fmt.Printf("%s: %s\n", path, strings.Join(issues, ", "))
+	}
+	return failed, nil
+}
+
+// readHardeningInput reads the manifest and, for APKs, the native libraries, which must not be compressed with
+// android:extractNativeLibs=false.
+func readHardeningInput(path string, fileType inputType) (*XmlNode, nativeLibs, error) {
+	r, size, closeInput, err := openInput(path)
+	if err != nil {
+		return nil, nativeLibs{}, err
+	}
+	defer closeInput()
+	if fileType, err = resolveInputType(r, size, fileType); err != nil {
+		return nil, nativeLibs{}, err
+	}
+	manifest, err := readManifestFrom(r, size, fileType)
+	if err != nil || fileType != inputTypeApk {
+		return manifest, nativeLibs{}, err
+	}
+	a, err := openArchive(r, size)
+	if err != nil {
+		return nil, nativeLibs{}, err
+	}
+	return manifest, readNativeLibs(a), nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHardeningIssues(t *testing.T) {
+	tests := []struct {
+		extractNativeLibs string
+		libs              nativeLibs
+		issues            []string
+	}{
+		{"", nativeLibs{compressed: true}, nil},
+		{"true", nativeLibs{compressed: true}, nil},
+		{"false", nativeLibs{}, nil},
+		{"false", nativeLibs{compressed: true}, []string{"android:extractNativeLibs=false with compressed native libraries"}},
+	}
+	for _, test := range tests {
+		manifest, err := parseBinaryXml(readBinaryXmlFixture(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		application := childElements(manifest.GetElement(), "application")[0]
+		removeAttribute(application, "debuggable")
+		if test.extractNativeLibs != "" {
+			if _, err := (&manifestEditor{}).setAttribute(application, "extractNativeLibs", test.extractNativeLibs); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if issues := hardeningIssues(manifest, test.libs, &Config{}); !reflect.DeepEqual(issues, test.issues) {
+			t.Errorf("extractNativeLibs=%q with %+v: got %q, want %q", test.extractNativeLibs, test.libs, issues, test.issues)
+		}
+	}
+}
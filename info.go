@@ -0,0 +1,346 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// bundleMetadataDir contains metadata of the build tools in AABs, e.g. the Android Gradle Plugin's version.
+const bundleMetadataDir = "BUNDLE-METADATA/"
+
+// manifestVersion is the version of a module's or APK's manifest.
+type manifestVersion struct {
+	name        string
+	packageName string
+	versionCode string
+	versionName string
+	// standalone is set for the standalone APKs of APK sets, which may have versionCode offsets for their ABI and
+	// density.
+	standalone bool
+}
+
+func newManifestVersion(name string, manifest *XmlNode) manifestVersion {
+	return manifestVersion{
+		name:        name,
+		packageName: getManifestAttribute(manifest, "", "package").GetValue(),
+		versionCode: attributeText(getManifestAttribute(manifest, namespace, versionCodeAttr)),
+		versionName: attributeText(getManifestAttribute(manifest, namespace, versionNameAttr)),
+	}
+}
+
+// metadataFile is a BUNDLE-METADATA or META-INF/*.version file with its (summarized) contents.
+type metadataFile struct {
+	name  string
+	value string
+}
+
+// versionInfo collects the version information of a file. The first manifest is the base module's.
+type versionInfo struct {
+	manifests         []manifestVersion
+	bundletoolVersion string
+	metadata          []metadataFile
+	buildConfigs      []buildConfig
+	warnings          []string
+}
+
+func infoCommand(args []string) {
+	flags := commandFlags("info", "[flags] file...")
+	typeName := flags.String("type", "", "The file type: apk, apks, aab, aar or manifest (detected from the contents by default)")
+	flags.Parse(args)
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	fileType := inputTypeAuto
+	if *typeName != "" {
+		var err error
+		if fileType, err = parseInputType(*typeName); err != nil {
+			fmt.Fprintln(flags.Output(), "Error:", err)
+			os.Exit(2)
+		}
+	}
+	paths, err := expandPaths(flags.Args())
+	if err != nil {
+		fmt.Fprintln(flags.Output(), "Error:", err)
+		os.Exit(2)
+	}
+
+	inconsistent := false
+	for _, path := range paths {
+		info, err := readVersionInfo(path, fileType)
+		if err != nil {
+			log.Fatalln("Failed to read", path+":", err)
+		}
+		if len(paths) > 1 {
+			fmt.Printf("== %s\n", path)
+		}
+		if info.write(os.Stdout) {
+			inconsistent = true
+		}
+	}
+	if inconsistent {
+		os.Exit(1)
+	}
+}
+
+// readVersionInfo collects the manifests' versions, the bundletool version, the BUNDLE-METADATA and
+// META-INF/*.version files and the BuildConfig constants of the app's code.
+func readVersionInfo(path string, fileType inputType) (*versionInfo, error) {
+	r, size, closeInput, err := openInput(path)
+	if err != nil {
+		return nil, err
+	}
+	defer closeInput()
+	if fileType, err = resolveInputType(r, size, fileType); err != nil {
+		return nil, err
+	}
+
+	info := &versionInfo{}
+	if fileType == inputTypeManifest {
+		data, err := ioutil.ReadAll(io.NewSectionReader(r, 0, size))
+		if err != nil {
+			return nil, err
+		}
+		return info, info.addManifest("manifest", data)
+	}
+	a, err := openArchive(r, size)
+	if err != nil {
+		return nil, err
+	}
+	switch fileType {
+	case inputTypeAab:
+		err = info.readAab(a)
+	case inputTypeApks:
+		err = info.readApks(a)
+	default:
+		err = info.readArchive(a, "AndroidManifest.xml", "", "META-INF/")
+	}
+	return info, err
+}
+
+func (info *versionInfo) addManifest(name string, data []byte) error {
+	format := detectXmlFormat(data)
+	manifest, err := decodeXml(data, format)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s (%s): %w", name, format, err)
+	}
+	info.manifests = append(info.manifests, newManifestVersion(name, manifest))
+	return nil
+}
+
+// readArchive reads the manifest, the dex files in dexDir and the *.version files in metaInfDir.
+func (info *versionInfo) readArchive(a *archive, manifestPath string, dexDir string, metaInfDir string) error {
+	data, err := a.read(manifestPath)
+	if err != nil {
+		return err
+	}
+	if err := info.addManifest(manifestPath, data); err != nil {
+		return err
+	}
+	for _, name := range a.names() {
+		dir, file := path.Split(name)
+		switch {
+		case dir == metaInfDir && strings.HasSuffix(file, ".version"):
+			data, err := a.read(name)
+			if err != nil {
+				return err
+			}
+			info.metadata = append(info.metadata, metadataFile{name, strings.TrimSpace(string(data))})
+		case dir == dexDir && strings.HasPrefix(file, "classes") && strings.HasSuffix(file, ".dex"):
+			data, err := a.read(name)
+			if err != nil {
+				return err
+			}
+			configs, err := readBuildConfigs(data)
+			if err != nil {
+				info.warnings = append(info.warnings, fmt.Sprintf("%s: %v", name, err))
+				continue
+			}
+			info.buildConfigs = append(info.buildConfigs, configs...)
+		}
+	}
+	return nil
+}
+
+func (info *versionInfo) readAab(a *archive) error {
+	if err := info.readArchive(a, "base/manifest/AndroidManifest.xml", "base/dex/", "base/root/META-INF/"); err != nil {
+		return err
+	}
+	names := a.names()
+	sort.Strings(names)
+	for _, name := range names {
+		if strings.HasSuffix(name, "/manifest/AndroidManifest.xml") && strings.Count(name, "/") == 2 && !strings.HasPrefix(name, "base/") {
+			data, err := a.read(name)
+			if err != nil {
+				return err
+			}
+			if err := info.addManifest(name, data); err != nil {
+				return err
+			}
+		}
+		if strings.HasPrefix(name, bundleMetadataDir) && !strings.HasSuffix(name, "/") {
+			data, err := a.read(name)
+			if err != nil {
+				return err
+			}
+			info.metadata = append(info.metadata, metadataFile{name, summarizeMetadata(name, data)})
+		}
+	}
+	if a.file(bundleConfigPath) != nil {
+		data, err := a.read(bundleConfigPath)
+		if err != nil {
+			return err
+		}
+		bundleConfig := &BundleConfig{}
+		if err := bundleConfig.UnmarshalVT(data); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", bundleConfigPath, err)
+		}
+		info.bundletoolVersion = bundleConfig.GetBundletool().GetVersion()
+	}
+	return nil
+}
+
+// readApks reads the manifests of all APKs, but the code only from the base module's master split.
+func (info *versionInfo) readApks(a *archive) error {
+	var tocApks map[string]tocApk
+	if a.file(apksTocPath) != nil {
+		toc, err := readApksToc(a)
+		if err != nil {
+			return err
+		}
+		info.bundletoolVersion = toc.GetBundletool().GetVersion()
+		tocApks = readTocApks(toc)
+	}
+
+	var apks []string
+	baseApk := ""
+	for _, name := range a.names() {
+		if !strings.HasSuffix(name, ".apk") {
+			continue
+		}
+		if baseApk == "" || strings.HasSuffix(name, "/base-master.apk") {
+			baseApk = name
+		}
+		apks = append(apks, name)
+	}
+	sort.Slice(apks, func(i, j int) bool {
+		if (apks[i] == baseApk) != (apks[j] == baseApk) {
+			return apks[i] == baseApk
+		}
+		return apks[i] < apks[j]
+	})
+	for _, name := range apks {
+		data, err := a.read(name)
+		if err != nil {
+			return err
+		}
+		apk, err := openArchive(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if name == baseApk {
+			if err = info.readArchive(apk, "AndroidManifest.xml", "", "META-INF/"); err == nil {
+				info.manifests[len(info.manifests)-1].name = name
+			}
+		} else {
+			var manifest []byte
+			if manifest, err = apk.read("AndroidManifest.xml"); err == nil {
+				err = info.addManifest(name, manifest)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		info.manifests[len(info.manifests)-1].standalone = tocApks[name].standalone
+	}
+	return nil
+}
+
+// summarizeMetadata returns the entries of .properties files and the size of all other files, which are usually
+// binary or large like the R8 mapping.
+func summarizeMetadata(name string, data []byte) string {
+	if !strings.HasSuffix(name, ".properties") {
+		return fmt.Sprintf("(%d bytes)", len(data))
+	}
+	var entries []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			entries = append(entries, line)
+		}
+	}
+	return strings.Join(entries, ", ")
+}
+
+// inconsistencies compares the versions of all manifests and of the app's BuildConfig with the base manifest. Only
+// the app's BuildConfig has an APPLICATION_ID, the libraries' ones are ignored. The versionCodes of standalone APKs
+// aren't compared because they may have offsets for their ABI and density.
+func (info *versionInfo) inconsistencies() []string {
+	if len(info.manifests) == 0 {
+		return nil
+	}
+	base := info.manifests[0]
+	var issues []string
+	compare := func(what string, name string, value string, baseValue string) {
+		if value != "" && baseValue != "" && value != baseValue {
+			issues = append(issues, fmt.Sprintf("%s %s is %s, but %s has %s", name, what, value, base.name, baseValue))
+		}
+	}
+	for _, m := range info.manifests[1:] {
+		compare("package", m.name, m.packageName, base.packageName)
+		if !m.standalone && !base.standalone {
+			compare("versionCode", m.name, m.versionCode, base.versionCode)
+		}
+		compare("versionName", m.name, m.versionName, base.versionName)
+	}
+	for _, config := range info.buildConfigs {
+		if _, ok := config.fields["APPLICATION_ID"]; !ok {
+			continue
+		}
+		compare("APPLICATION_ID", config.className, config.fields["APPLICATION_ID"], base.packageName)
+		if !base.standalone {
+			compare("VERSION_CODE", config.className, config.fields["VERSION_CODE"], base.versionCode)
+		}
+		compare("VERSION_NAME", config.className, config.fields["VERSION_NAME"], base.versionName)
+	}
+	return issues
+}
+
+// write prints the info and reports whether it's inconsistent.
+func (info *versionInfo) write(w io.Writer) bool {
+	for _, m := range info.manifests {
+		fmt.Fprintf(w, "%s: package=%s versionCode=%s versionName=%s\n", m.name, m.packageName, m.versionCode, m.versionName)
+	}
+	if info.bundletoolVersion != "" {
+		fmt.Fprintf(w, "bundletool: %s\n", info.bundletoolVersion)
+	}
+	for _, file := range info.metadata {
+		fmt.Fprintf(w, "%s: %s\n", file.name, file.value)
+	}
+	for _, config := range info.buildConfigs {
+		var fields []string
+		for _, name := range buildConfigFields {
+			if value, ok := config.fields[name]; ok {
+				fields = append(fields, name+"="+value)
+			}
+		}
+		fmt.Fprintf(w, "%s: %s\n", config.className, strings.Join(fields, ", "))
+	}
+	for _, warning := range info.warnings {
+		fmt.Fprintln(w, "Warning:", warning)
+	}
+	issues := info.inconsistencies()
+	for _, issue := range issues {
+		fmt.Fprintln(w, "Inconsistent:", issue)
+	}
+	return len(issues) > 0
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dataAttrs are the attributes of an intent filter's <data> element.
+var dataAttrs = []string{
+	"scheme", "host", "port", "path", "pathPrefix", "pathPattern", "pathSuffix", "pathAdvancedPattern", "mimeType",
+}
+
+// intentFilterSpec selects intent filters by component, actions and categories and describes the changes, given as
+// ;-separated parts like
+// component=.MainActivity;action=android.intent.action.VIEW;rewrite-host=example.com->customer.com;autoVerify=true.
+type intentFilterSpec struct {
+	component  string
+	actions    []string
+	categories []string
+
+	autoVerify  string
+	removeData  [][]attributeSpec
+	rewriteData []dataRewrite
+	addData     [][]attributeSpec
+}
+
+// dataRewrite replaces the value of a <data> attribute. An old value of * matches all values.
+type dataRewrite struct {
+	attr     string
+	oldValue string
+	newValue string
+}
+
+func parseIntentFilterSpec(spec string) (intentFilterSpec, error) {
+	var f intentFilterSpec
+	invalid := func(format string, a ...interface{}) (intentFilterSpec, error) {
+		return f, fmt.Errorf("invalid intent filter %q: %s", spec, fmt.Sprintf(format, a...))
+	}
+	for _, part := range strings.Split(spec, ";") {
+		part = strings.TrimSpace(part)
+		i := strings.IndexByte(part, '=')
+		if i <= 0 {
+			return invalid("expected key=value instead of %q", part)
+		}
+		key, value := part[:i], part[i+1:]
+		switch {
+		case key == "component":
+			f.component = value
+		case key == "action":
+			f.actions = append(f.actions, value)
+		case key == "category":
+			f.categories = append(f.categories, value)
+		case key == "autoVerify":
+			if value != "true" && value != "false" {
+				return invalid("autoVerify must be true or false")
+			}
+			f.autoVerify = value
+		case key == "add-data" || key == "remove-data":
+			attrs, err := parseDataAttrs(value)
+			if err != nil {
+				return invalid("%v", err)
+			}
+			if key == "add-data" {
+				f.addData = append(f.addData, attrs)
+			} else {
+				f.removeData = append(f.removeData, attrs)
+			}
+		case strings.HasPrefix(key, "rewrite-"):
+			attr := strings.TrimPrefix(key, "rewrite-")
+			if !containsString(dataAttrs, attr) {
+				return invalid("unknown <data> attribute %s (expected %s)", attr, strings.Join(dataAttrs, ", "))
+			}
+			values := strings.SplitN(value, "->", 2)
+			if len(values) != 2 {
+				return invalid("expected %s=old->new", key)
+			}
+			f.rewriteData = append(f.rewriteData, dataRewrite{attr, values[0], values[1]})
+		default:
+			return invalid("unknown key %s", key)
+		}
+	}
+	if f.autoVerify == "" && len(f.addData) == 0 && len(f.removeData) == 0 && len(f.rewriteData) == 0 {
+		return invalid("no changes given (use autoVerify, add-data, remove-data or rewrite-<attribute>)")
+	}
+	return f, nil
+}
+
+// parseDataAttrs parses <data> attributes like scheme=https,host=example.com.
+func parseDataAttrs(value string) ([]attributeSpec, error) {
+	var attrs []attributeSpec
+	for _, part := range strings.Split(value, ",") {
+		attr, err := parseAttributeSpec(part)
+		if err != nil {
+			return nil, err
+		}
+		if !containsString(dataAttrs, attr.name) {
+			return nil, fmt.Errorf("unknown <data> attribute %s (expected %s)", attr.name, strings.Join(dataAttrs, ", "))
+		}
+		attrs = append(attrs, attr)
+	}
+	return attrs, nil
+}
+
+func formatDataAttrs(attrs []attributeSpec) string {
+	var parts []string
+	for _, attr := range attrs {
+		parts = append(parts, attr.name+"="+attr.value)
+	}
+	return strings.Join(parts, ",")
+}
+
+// matches reports whether the intent filter has all the selected actions and categories.
+func (f intentFilterSpec) matches(filter *XmlElement) bool {
+	has := func(tag string, name string) bool {
+		for _, element := range childElements(filter, tag) {
+			if findAttribute(element, namespace, "name").GetValue() == name {
+				return true
+			}
+		}
+		return false
+	}
+	for _, action := range f.actions {
+		if !has("action", action) {
+			return false
+		}
+	}
+	for _, category := range f.categories {
+		if !has("category", category) {
+			return false
+		}
+	}
+	return true
+}
+
+// selectIntentFilters returns the intent filters of the selected component or, without component, of all components.
+func (f intentFilterSpec) selectIntentFilters(root *XmlElement, packageName string) []elementMatch {
+	var components []elementMatch
+	if f.component != "" {
+		components = findComponents(root, packageName, f.component)
+	} else {
+		for _, application := range childElements(root, "application") {
+			for _, child := range application.GetChild() {
+				if element := child.GetElement(); containsString(componentTags, element.GetName()) {
+					components = append(components, elementMatch{element, elementPath("/manifest/application", element)})
+				}
+			}
+		}
+	}
+	var filters []elementMatch
+	for _, component := range components {
+		for _, filter := range childElements(component.element, "intent-filter") {
+			if f.matches(filter) {
+				filters = append(filters, elementMatch{filter, component.path + "/intent-filter"})
+			}
+		}
+	}
+	return filters
+}
+
+// editIntentFilters applies the intent filter changes. The other elements of the filters are kept as they are.
+func editIntentFilters(xmlNode *XmlNode, editor *manifestEditor, config *Config) error {
+	for _, spec := range config.intentFilters {
+		filters := spec.selectIntentFilters(xmlNode.GetElement(), editor.linker.packageName)
+		if len(filters) == 0 {
+			return fmt.Errorf("no intent filter found for component=%q, actions %v and categories %v", spec.component, spec.actions, spec.categories)
+		}
+		for _, filter := range filters {
+			if err := editIntentFilter(filter, spec, editor, config); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func editIntentFilter(filter elementMatch, spec intentFilterSpec, editor *manifestEditor, config *Config) error {
+	for _, attrs := range spec.removeData {
+		children := filter.element.Child[:0]
+		for _, child := range filter.element.Child {
+			if element := child.GetElement(); element.GetName() == "data" && dataMatches(element, attrs) {
+				config.println("Removing <data "+formatDataAttrs(attrs)+"> from", filter.path)
+				continue
+			}
+			children = append(children, child)
+		}
+		filter.element.Child = children
+	}
+
+	for _, rewrite := range spec.rewriteData {
+		rewritten := false
+		for _, data := range childElements(filter.element, "data") {
+			attr := findAttribute(data, namespace, rewrite.attr)
+			if attr == nil || rewrite.oldValue != "*" && attributeText(attr) != rewrite.oldValue {
+				continue
+			}
+			oldValue, err := editor.setAttribute(data, rewrite.attr, rewrite.newValue)
+			if err != nil {
+				return err
+			}
+			config.println("Changing <data>", rewrite.attr, "in", filter.path, "from", oldValue, "to", rewrite.newValue)
+			rewritten = true
+		}
+		if !rewritten {
+			config.println("Warning: no <data>", rewrite.attr+"="+rewrite.oldValue, "in", filter.path)
+		}
+	}
+
+	for _, attrs := range spec.addData {
+		config.println("Adding <data "+formatDataAttrs(attrs)+"> to", filter.path)
+		data := addElement(filter.element, "data", -1)
+		for _, attr := range attrs {
+			if _, err := editor.setAttribute(data, attr.name, attr.value); err != nil {
+				return err
+			}
+		}
+	}
+
+	if spec.autoVerify != "" {
+		oldValue, err := editor.setAttribute(filter.element, "autoVerify", spec.autoVerify)
+		if err != nil {
+			return err
+		}
+		if oldValue != spec.autoVerify {
+			config.println("Setting autoVerify of", filter.path, "to", spec.autoVerify)
+		}
+	}
+	return nil
+}
+
+// dataMatches reports whether the <data> element has all the given attribute values.
+func dataMatches(data *XmlElement, attrs []attributeSpec) bool {
+	for _, attr := range attrs {
+		existing := findAttribute(data, namespace, attr.name)
+		if existing == nil || attr.value != "*" && attributeText(existing) != attr.value {
+			return false
+		}
+	}
+	return true
+}
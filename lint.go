@@ -0,0 +1,296 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+)
+
+// lintRule describes a rule reported by validateManifest or lintManifest.
+type lintRule struct {
+	id          string
+	description string
+}
+
+var lintRules = []lintRule{
+	{"package-name", "The package name must be a valid Java package name with at least two segments"},
+	{"version-code", "The versionCode must be positive and at most 2100000000"},
+	{"sdk-versions", "The minSdkVersion must not be higher than the targetSdkVersion and maxSdkVersion"},
+	{"permission-name", "Permissions must have an android:name"},
+	{"duplicate-permission", "Permissions must not be declared or requested twice"},
+	{"exported-required", "Components with intent filters must set android:exported when targeting API 31+"},
+	{"debuggable", "Release builds must not be debuggable"},
+	{"cleartext-traffic", "Cleartext network traffic should be disabled"},
+	{"allow-backup", "Backups should be disabled or limited with backup rules"},
+	{"sensitive-permission", "The permission is restricted by Google Play policies and needs a declaration"},
+	{"exported-provider", "Exported content providers should be protected by a permission"},
+	{"policy-package", "The package must match the policy"},
+	{"policy-target-sdk", "The targetSdkVersion must be at least the policy's minTargetSdk"},
+	{"policy-permission", "Only the policy's allowedPermissions may be requested"},
+	{"policy-attribute", "The policy's forbiddenAttributes must not be set"},
+}
+
+// sensitivePermissions are restricted by Google Play policies.
+var sensitivePermissions = map[string]string{
+	"android.permission.SEND_SMS":                   "SMS",
+	"android.permission.RECEIVE_SMS":                "SMS",
+	"android.permission.READ_SMS":                   "SMS",
+	"android.permission.RECEIVE_MMS":                "SMS",
+	"android.permission.RECEIVE_WAP_PUSH":           "SMS",
+	"android.permission.READ_CALL_LOG":              "call log",
+	"android.permission.WRITE_CALL_LOG":             "call log",
+	"android.permission.PROCESS_OUTGOING_CALLS":     "call log",
+	"android.permission.ACCESS_BACKGROUND_LOCATION": "background location",
+	"android.permission.QUERY_ALL_PACKAGES":         "package visibility",
+	"android.permission.MANAGE_EXTERNAL_STORAGE":    "all files access",
+	"android.permission.REQUEST_INSTALL_PACKAGES":   "request install packages",
+}
+
+// lintManifest looks for issues which are valid for the platform, but relevant for security or Google Play policies.
+func lintManifest(manifest *XmlNode) []manifestIssue {
+	var issues []manifestIssue
+	report := func(rule string, severity severity, path string, format string, a ...interface{}) {
+		issues = append(issues, manifestIssue{rule, severity, path, fmt.Sprintf(format, a...)})
+	}
+	root := manifest.GetElement()
+	rootPath := "/" + root.GetName()
+
+	for _, element := range childElements(root, "uses-permission") {
+		name := findAttribute(element, namespace, "name").GetValue()
+		if category, ok := sensitivePermissions[name]; ok {
+			report("sensitive-permission", severityWarning, elementPath(rootPath, element),
+				"%s requires a Google Play %s permission declaration", name, category)
+		}
+	}
+
+	for _, application := range childElements(root, "application") {
+		path := rootPath + "/application"
+		if debuggable, _ := attributeBool(findAttribute(application, namespace, "debuggable")); debuggable {
+			report("debuggable", severityError, path, "android:debuggable is true")
+		}
+		if cleartext, _ := attributeBool(findAttribute(application, namespace, "usesCleartextTraffic")); cleartext {
+			report("cleartext-traffic", severityWarning, path, "android:usesCleartextTraffic is true")
+		}
+		// Backups are enabled by default.
+		allowBackup, ok := attributeBool(findAttribute(application, namespace, "allowBackup"))
+		if (allowBackup || !ok) && findAttribute(application, namespace, "fullBackupContent") == nil &&
+			findAttribute(application, namespace, "dataExtractionRules") == nil {
+			report("allow-backup", severityWarning, path,
+				"backups are enabled without android:fullBackupContent or android:dataExtractionRules")
+		}
+
+		for _, provider := range childElements(application, "provider") {
+			if exported, _ := attributeBool(findAttribute(provider, namespace, "exported")); !exported {
+				continue
+			}
+			if findAttribute(provider, namespace, "permission") == nil &&
+				findAttribute(provider, namespace, "readPermission") == nil &&
+				findAttribute(provider, namespace, "writePermission") == nil {
+				report("exported-provider", severityWarning, elementPath(path, provider),
+					"exported provider without android:permission, android:readPermission or android:writePermission")
+			}
+		}
+	}
+	return issues
+}
+
+// lintResult are the issues found in one file.
+type lintResult struct {
+	path   string
+	issues []manifestIssue
+}
+
+// lintCommand reports validation and policy issues of the manifests of the given files.
+func lintCommand(args []string) {
+	flags := commandFlags("lint", "[flags] file...")
+	format := flags.String("format", "text", "The output format: text, json or sarif")
+	typeName := flags.String("type", "", "The file type: apk, apks, aab, aar or manifest (detected from the contents by default)")
+	failOn := flags.String("fail-on", "error", "Exit with status 1 if there are issues of this severity: error or warning")
+	flags.Parse(args)
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	write, err := lintWriter(*format)
+	if err != nil {
+		fmt.Fprintln(flags.Output(), "Error:", err)
+		os.Exit(2)
+	}
+	minSeverity := severityError
+	switch *failOn {
+	case "error":
+	case "warning":
+		minSeverity = severityWarning
+	default:
+		fmt.Fprintln(flags.Output(), "Error: unknown severity", *failOn)
+		os.Exit(2)
+	}
+	fileType := inputTypeAuto
+	if *typeName != "" {
+		if fileType, err = parseInputType(*typeName); err != nil {
+			fmt.Fprintln(flags.Output(), "Error:", err)
+			os.Exit(2)
+		}
+	}
+	paths, err := expandPaths(flags.Args())
+	if err != nil {
+		fmt.Fprintln(flags.Output(), "Error:", err)
+		os.Exit(2)
+	}
+
+	var results []lintResult
+	failed := false
+	for _, path := range paths {
+		manifest, _, err := readManifest(path, fileType)
+		if err != nil {
+			log.Fatalln("Failed to read", path+":", err)
+		}
+		issues := append(validateManifest(manifest), lintManifest(manifest)...)
+		for _, issue := range issues {
+			if issue.severity >= minSeverity {
+				failed = true
+			}
+		}
+		results = append(results, lintResult{path, issues})
+	}
+	if err := write(os.Stdout, results); err != nil {
+		log.Fatalln("Error:", err)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// lintWriter returns the function which writes the results in the given format.
+func lintWriter(format string) (func(w io.Writer, results []lintResult) error, error) {
+	switch format {
+	case "text":
+		return writeLintText, nil
+	case "json":
+		return writeLintJson, nil
+	case "sarif":
+		return writeLintSarif, nil
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+func writeLintText(w io.Writer, results []lintResult) error {
+	for _, result := range results {
+		for _, issue := range result.issues {
+			if _, err := fmt.Fprintf(w, "%s: %s\n", result.path, issue); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type lintJsonIssue struct {
+	File     string `json:"file"`
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Path     string `json:"path"`
+	Message  string `json:"message"`
+}
+
+func writeLintJson(w io.Writer, results []lintResult) error {
+	issues := []lintJsonIssue{}
+	for _, result := range results {
+		for _, issue := range result.issues {
+			issues = append(issues, lintJsonIssue{result.path, issue.rule, issue.severity.String(), issue.path, issue.message})
+		}
+	}
+	return writeJson(w, issues)
+}
+
+// The subset of SARIF 2.1.0 which is needed for reporting the issues, e.g. to GitHub code scanning.
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationUri string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleId    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+func writeLintSarif(w io.Writer, results []lintResult) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "androidmanifest-changer",
+			InformationUri: "https://github.com/ensody/androidmanifest-changer",
+		}},
+		Results: []sarifResult{},
+	}
+	for _, rule := range lintRules {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{rule.id, sarifMessage{rule.description}})
+	}
+	for _, result := range results {
+		for _, issue := range result.issues {
+			run.Results = append(run.Results, sarifResult{
+				RuleId:  issue.rule,
+				Level:   issue.severity.String(),
+				Message: sarifMessage{issue.message},
+				Locations: []sarifLocation{{
+					PhysicalLocation: sarifPhysicalLocation{sarifArtifactLocation{strings.TrimPrefix(result.path, "./")}},
+					LogicalLocations: []sarifLogicalLocation{{issue.path, "element"}},
+				}},
+			})
+		}
+	}
+	return writeJson(w, sarifLog{"2.1.0", "https://json.schemastore.org/sarif-2.1.0.json", []sarifRun{run}})
+}
+
+func writeJson(w io.Writer, value interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
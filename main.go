@@ -2,6 +2,7 @@ package main
 
 import (
 	"archive/zip"
+	"bytes"
 	"errors"
 	"flag"
 	"fmt"
@@ -10,15 +11,15 @@ import (
 	"log"
 	"os"
 	"os/exec"
-	"path"
 	"path/filepath"
+	"runtime"
 	"strings"
-
-	"google.golang.org/protobuf/proto"
 )
 
 const (
 	namespace       = "http://schemas.android.com/apk/res/android"
+	toolsNamespace  = "http://schemas.android.com/tools"
+	autoNamespace   = "http://schemas.android.com/apk/res-auto"
 	versionCodeAttr = "versionCode"
 	versionNameAttr = "versionName"
 )
@@ -26,127 +27,728 @@ const (
 var tmpDir = os.TempDir()
 
 type Config struct {
-	versionCode int32
-	versionName string
-	packageName string
+	versionCode       int32
+	versionName       string
+	packageName       string
+	minSdk            string
+	targetSdk         string
+	maxSdk            string
+	addPermissions    []permissionSpec
+	removePermissions []permissionSpec
+	addFeatures       []featureSpec
+	removeFeatures    []featureSpec
+	featureRequired   []featureToggle
+	supportsScreens   []attributeSpec
+	components        []componentSpec
+	intentFilters     []intentFilterSpec
+	metaData          []metaDataEdit
+	removeMetaData    []string
+	// networkSecurityConfig is a reference or "remove". If networkSecurityConfigXml is set, it is injected as
+	// @xml/network_security_config.
+	networkSecurityConfig    string
+	networkSecurityConfigXml []byte
+	cleartextTraffic         string
+	// harden removes debug flags for release builds. disallowBackup sets allowBackup to false.
+	harden         bool
+	disallowBackup bool
+	// makeDebuggable and profileable prepare release builds for troubleshooting.
+	makeDebuggable bool
+	profileable    bool
+	// signing re-signs modified APKs if set.
+	signing *signingConfig
+	// versionCodeOffsets are added to the versionCode depending on the APK's ABI and density.
+	versionCodeOffsets map[string]int32
+
+	bundleConfigEdits []bundleConfigEdit
+	keepLocales       []string
+	keepDensities     []uint32
+	skipValidation    bool
+	// linker has the framework attributes, which are needed for adding attributes to compiled manifests.
+	linker *resourceLinker
+	// placeholders maps the keys of ${key} placeholders to their values.
+	placeholders map[string]string
+	// out receives the messages about the changes. It is buffered per file when processing multiple files.
+	out io.Writer
+}
+
+func (c *Config) stripsResources() bool {
+	return len(c.keepLocales) > 0 || len(c.keepDensities) > 0
+}
+
+// referencesResources reports whether the edits need the app's resources for resolving references.
+func (c *Config) referencesResources() bool {
+	for _, edit := range c.metaData {
+		if edit.reference {
+			return true
+		}
+	}
+	return isReference(c.networkSecurityConfig)
+}
+
+func (c *Config) println(a ...interface{}) {
+	fmt.Fprintln(c.out, a...)
+}
+
+func (c *Config) printf(format string, a ...interface{}) {
+	fmt.Fprintf(c.out, format, a...)
+}
+
+// stringList is a flag.Value that collects every occurrence of a repeatable flag.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// commands are the subcommands which are given as first argument instead of a file.
+var commands = map[string]func(args []string){
+	"compile":   compileCommand,
+	"decompile": decompileCommand,
+	"lint":      lintCommand,
+	"check":     checkCommand,
+	"info":      infoCommand,
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			command(os.Args[2:])
+			return
+		}
+	}
+
 	versionCode := flag.Uint("versionCode", 0, "The versionCode to set")
+	versionCodeOffsets := flag.String("versionCode-offsets", "", "Add offsets to the versionCode per ABI and density of the APKs, e.g. arm64-v8a=2000,armeabi-v7a=1000")
+	checkVersionCodesFlag := flag.Bool("check-version-codes", false, "Print the versionCodes of the APKs and APK sets and check them for conflicts instead of modifying the files")
 	versionName := flag.String("versionName", "", "The versionName to set")
 	packageName := flag.String("package", "", "The package to set")
+	minSdk := flag.String("minSdk", "", "The minSdkVersion to set (an API level or a preview codename)")
+	targetSdk := flag.String("targetSdk", "", "The targetSdkVersion to set (an API level or a preview codename)")
+	maxSdk := flag.String("maxSdk", "", "The maxSdkVersion to set")
+	var bundleConfigExprs stringList
+	flag.Var(&bundleConfigExprs, "bundle-config", "A BundleConfig.pb edit like split.language=false or uncompressedGlob+=res/raw/** (AAB only, repeatable)")
+	keepLocales := flag.String("keep-locales", "", "Comma-separated list of locales to keep (e.g. de,en-rUS); all other translations are removed")
+	keepDensities := flag.String("keep-densities", "", "Comma-separated list of densities to keep (e.g. xxhdpi,xxxhdpi); all other density-specific resources are removed")
+	var addPermissions, removePermissions stringList
+	flag.Var(&addPermissions, "add-permission", "Add a permission as [element:]name[,attr=value...], e.g. android.permission.BLUETOOTH,maxSdkVersion=30 (repeatable)")
+	flag.Var(&removePermissions, "remove-permission", "Remove a permission as [element:]name (repeatable)")
+	listPermissions := flag.Bool("list-permissions", false, "Print the permissions instead of modifying the files")
+	var addFeatures, removeFeatures, featureRequired stringList
+	flag.Var(&addFeatures, "add-feature", "Add a <uses-feature> as name[,required=false] or glEsVersion=3.1 (repeatable)")
+	flag.Var(&removeFeatures, "remove-feature", "Remove a <uses-feature> by name or glEsVersion (repeatable)")
+	flag.Var(&featureRequired, "feature-required", "Set whether a feature is required as name=true|false (repeatable)")
+	supportsScreens := flag.String("supports-screens", "", "Set <supports-screens> attributes like smallScreens=false,xlargeScreens=true")
+	var components stringList
+	flag.Var(&components, "component", "Change a component's enabled, exported, permission or process attribute, e.g. .DebugActivity,enabled=false (repeatable)")
+	var intentFilters stringList
+	flag.Var(&intentFilters, "intent-filter", "Edit intent filters, e.g. component=.MainActivity;action=android.intent.action.VIEW;rewrite-host=old.com->new.com (repeatable)")
+	var metaData, metaDataRefs, removeMetaData stringList
+	flag.Var(&metaData, "meta-data", "Set an <application> <meta-data> value as name=value (repeatable)")
+	flag.Var(&metaDataRefs, "meta-data-ref", "Set an <application> <meta-data> resource as name=@type/name (repeatable)")
+	flag.Var(&removeMetaData, "remove-meta-data", "Remove an <application> <meta-data> by name (repeatable)")
+	networkSecurityConfig := flag.String("network-security-config", "", "Set android:networkSecurityConfig to a reference like @xml/config, inject a network security config XML file or remove the attribute with \"remove\"")
+	cleartextTraffic := flag.String("cleartext-traffic", "", "Set android:usesCleartextTraffic to true or false or remove it with \"remove\"")
+	harden := flag.Bool("harden", false, "Remove android:debuggable, android:testOnly and android:extractNativeLibs=false with compressed native libraries for release builds")
+	disallowBackup := flag.Bool("disallow-backup", false, "Set android:allowBackup to false (also checked by --check-hardened)")
+	checkHardenedFlag := flag.Bool("check-hardened", false, "Check that the files have no debug flags which --harden would remove instead of modifying them")
+	makeDebuggable := flag.Bool("make-debuggable", false, "Set android:debuggable to true (APKs are re-signed, by default with the debug keystore)")
+	profileable := flag.Bool("profileable", false, "Add <profileable android:shell=\"true\"> (APKs are re-signed, by default with the debug keystore)")
+	keystore := flag.String("ks", "", "Re-sign modified APKs with this keystore via apksigner")
+	keystorePass := flag.String("ks-pass", "", "The keystore password in apksigner's format (pass:..., env:... or file:...), defaults to pass:android for the debug keystore")
+	keyAlias := flag.String("ks-key-alias", "", "The alias of the signing key within the keystore")
+	keyPass := flag.String("key-pass", "", "The key password in apksigner's format (defaults to the keystore password)")
+	listFeatures := flag.Bool("list-features", false, "Print the features and supported screens instead of modifying the files")
+	androidJar := flag.String("android-jar", "", "The android.jar from the Android SDK for adding attributes which aren't built in")
+	resources := flag.String("resources", "", "A resources.pb or AAB to resolve resource references in standalone manifests")
+	var placeholderExprs stringList
+	flag.Var(&placeholderExprs, "placeholder", "Replace the manifest placeholder ${key} with a value, given as key=value (repeatable)")
+	typeName := flag.String("type", "", "The file type: apk, apks, aab, aar or manifest (detected from the contents by default)")
+	skipValidation := flag.Bool("skip-validation", false, "Don't fail on invalid manifests (e.g. a versionCode above Google Play's limit)")
+	jobs := flag.Int("jobs", runtime.NumCPU(), "The number of files to process concurrently")
 	flag.Parse()
-	if len(flag.Args()) != 1 {
+	if len(flag.Args()) == 0 {
 		fmt.Fprintln(flag.CommandLine.Output(), "Error: File path is required.")
 		flag.Usage()
 		os.Exit(2)
 	}
+	if *versionCode > maxVersionCode {
+		usageError(fmt.Errorf("versionCode %d exceeds Google Play's limit of %d", *versionCode, maxVersionCode))
+	}
 	config := &Config{
-		versionCode: int32(*versionCode),
-		versionName: *versionName,
-		packageName: *packageName,
+		versionCode:    int32(*versionCode),
+		versionName:    *versionName,
+		packageName:    *packageName,
+		skipValidation: *skipValidation,
+		out:            os.Stdout,
+	}
+	for _, expr := range bundleConfigExprs {
+		edit, err := parseBundleConfigEdit(expr)
+		if err != nil {
+			usageError(err)
+		}
+		config.bundleConfigEdits = append(config.bundleConfigEdits, edit)
+	}
+	for _, spec := range addPermissions {
+		p, err := parsePermissionSpec(spec, true)
+		if err != nil {
+			usageError(err)
+		}
+		config.addPermissions = append(config.addPermissions, p)
+	}
+	for _, spec := range removePermissions {
+		p, err := parsePermissionSpec(spec, false)
+		if err != nil {
+			usageError(err)
+		}
+		config.removePermissions = append(config.removePermissions, p)
+	}
+	for _, spec := range addFeatures {
+		f, err := parseFeatureSpec(spec, true)
+		if err != nil {
+			usageError(err)
+		}
+		config.addFeatures = append(config.addFeatures, f)
+	}
+	for _, spec := range removeFeatures {
+		f, err := parseFeatureSpec(spec, false)
+		if err != nil {
+			usageError(err)
+		}
+		config.removeFeatures = append(config.removeFeatures, f)
+	}
+	for _, spec := range featureRequired {
+		toggle, err := parseFeatureToggle(spec)
+		if err != nil {
+			usageError(err)
+		}
+		config.featureRequired = append(config.featureRequired, toggle)
+	}
+	if *supportsScreens != "" {
+		var err error
+		if config.supportsScreens, err = parseSupportsScreens(*supportsScreens); err != nil {
+			usageError(err)
+		}
+	}
+	for _, spec := range components {
+		c, err := parseComponentSpec(spec)
+		if err != nil {
+			usageError(err)
+		}
+		config.components = append(config.components, c)
+	}
+	for _, spec := range intentFilters {
+		f, err := parseIntentFilterSpec(spec)
+		if err != nil {
+			usageError(err)
+		}
+		config.intentFilters = append(config.intentFilters, f)
+	}
+	for _, spec := range metaData {
+		edit, err := parseMetaDataEdit(spec, false)
+		if err != nil {
+			usageError(err)
+		}
+		config.metaData = append(config.metaData, edit)
+	}
+	for _, spec := range metaDataRefs {
+		edit, err := parseMetaDataEdit(spec, true)
+		if err != nil {
+			usageError(err)
+		}
+		config.metaData = append(config.metaData, edit)
+	}
+	config.removeMetaData = removeMetaData
+	config.harden = *harden
+	config.disallowBackup = *disallowBackup
+	config.makeDebuggable = *makeDebuggable
+	config.profileable = *profileable
+	if config.harden && (config.makeDebuggable || config.profileable) {
+		usageError(errors.New("--harden can't be combined with --make-debuggable and --profileable"))
+	}
+	if *keystore != "" && *keystorePass == "" {
+		// apksigner would prompt for the password, but its output isn't shown.
+		usageError(errors.New("--ks requires --ks-pass"))
+	}
+	if *keystore != "" || config.makeDebuggable || config.profileable {
+		config.signing = &signingConfig{keystore: *keystore, keystorePass: *keystorePass, keyAlias: *keyAlias, keyPass: *keyPass}
+	}
+	config.linker = newResourceLinker()
+	if *androidJar != "" {
+		if err := config.linker.loadAndroidJar(*androidJar); err != nil {
+			log.Fatalln("Failed to load", *androidJar+":", err)
+		}
+	}
+	if *resources != "" {
+		if err := config.linker.loadResources(*resources); err != nil {
+			log.Fatalln("Failed to load", *resources+":", err)
+		}
+	}
+	config.placeholders = map[string]string{}
+	for _, expr := range placeholderExprs {
+		if err := parsePlaceholder(expr, config.placeholders); err != nil {
+			usageError(err)
+		}
+	}
+	var err error
+	if config.minSdk, err = parseSdkVersion(*minSdk, true); err != nil {
+		usageError(err)
+	}
+	if config.targetSdk, err = parseSdkVersion(*targetSdk, true); err != nil {
+		usageError(err)
+	}
+	if config.maxSdk, err = parseSdkVersion(*maxSdk, false); err != nil {
+		usageError(err)
+	}
+	if config.keepLocales, err = parseLocales(*keepLocales); err != nil {
+		usageError(err)
+	}
+	if config.keepDensities, err = parseDensities(*keepDensities); err != nil {
+		usageError(err)
+	}
+	if config.versionCodeOffsets, err = parseVersionCodeOffsets(*versionCodeOffsets); err != nil {
+		usageError(err)
+	}
+	if config.versionCodeOffsets != nil && config.versionCode == 0 {
+		usageError(errors.New("--versionCode-offsets requires --versionCode"))
+	}
+	if config.networkSecurityConfig, config.networkSecurityConfigXml, err = parseNetworkSecurityConfig(*networkSecurityConfig); err != nil {
+		usageError(err)
+	}
+	if config.cleartextTraffic, err = parseCleartextTraffic(*cleartextTraffic); err != nil {
+		usageError(err)
 	}
 
-	path := flag.Arg(0)
+	fileType := inputTypeAuto
+	if *typeName != "" {
+		if fileType, err = parseInputType(*typeName); err != nil {
+			usageError(err)
+		}
+	}
+	paths, err := expandPaths(flag.Args())
+	if err != nil {
+		usageError(err)
+	}
 
-	if strings.HasSuffix(path, ".apk") {
-		updateApk(path, config)
-	} else if strings.HasSuffix(path, ".aab") {
-		updateAab(path, config)
-	} else {
-		updateManifest(path, config)
+	if *checkVersionCodesFlag {
+		conflicts, err := checkVersionCodes(paths, fileType)
+		if err != nil {
+			log.Fatalln("Error:", err)
+		}
+		if conflicts > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+	if *checkHardenedFlag {
+		failed, err := checkHardened(paths, fileType, config)
+		if err != nil {
+			log.Fatalln("Error:", err)
+		}
+		if failed > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	var listTags []string
+	if *listPermissions {
+		listTags = append(listTags, permissionTags...)
+	}
+	if *listFeatures {
+		listTags = append(listTags, "uses-feature", "supports-screens")
+	}
+	if len(listTags) > 0 {
+		for _, path := range paths {
+			manifest, _, err := readManifest(path, fileType)
+			if err != nil {
+				log.Fatalln("Error:", err)
+			}
+			if len(paths) > 1 {
+				fmt.Printf("== %s\n", path)
+			}
+			listElements(os.Stdout, manifest, listTags...)
+		}
+		return
+	}
+
+	if len(paths) == 1 {
+		if paths[0] == stdinPath {
+			// stdout receives the resulting file.
+			config.out = os.Stderr
+		}
+		if _, err := processFile(paths[0], fileType, config); err != nil {
+			log.Fatalln("Error:", err)
+		}
+		return
+	}
+	for _, path := range paths {
+		if path == stdinPath {
+			usageError(errors.New("stdin (-) can't be combined with other files"))
+		}
+	}
+	if *jobs < 1 {
+		usageError(errors.New("-jobs must be at least 1"))
+	}
+	results := processFiles(paths, fileType, config, *jobs)
+	if printSummary(results) > 0 {
+		os.Exit(1)
 	}
 }
 
-func updateApk(path string, config *Config) {
-	file, err := ioutil.TempFile(tmpDir, "*.aar")
+func usageError(err error) {
+	fmt.Fprintln(flag.CommandLine.Output(), "Error:", err)
+	os.Exit(2)
+}
+
+var errBundleConfigEdits = errors.New("BundleConfig edits are only supported for AAB files")
+
+var errNetworkSecurityConfigInjection = errors.New("network security config files can only be injected into APK, AAB and AAR files (use a reference like @xml/network_security_config instead)")
+
+// stdinPath as file path reads the file from stdin and writes the result to stdout.
+const stdinPath = "-"
+
+// processFile detects the file type (unless given) and applies the config.
+func processFile(path string, fileType inputType, config *Config) (inputType, error) {
+	r, size, closeInput, err := openInput(path)
 	if err != nil {
-		log.Fatalln("Failed creating temp file:", err)
+		return fileType, err
+	}
+	defer closeInput()
+	if fileType, err = resolveInputType(r, size, fileType); err != nil {
+		return fileType, err
 	}
-	defer os.Remove(file.Name())
 
-	out, err := exec.Command("aapt2", "convert", "-o", file.Name(), "--output-format", "proto", path).CombinedOutput()
+	var write func(w io.Writer) error
+	switch fileType {
+	case inputTypeManifest, inputTypeApk:
+		in, err := ioutil.ReadAll(io.NewSectionReader(r, 0, size))
+		if err != nil {
+			return fileType, err
+		}
+		var out []byte
+		if fileType == inputTypeApk {
+			out, _, err = updateApk(in, config)
+		} else if config.networkSecurityConfigXml != nil {
+			err = errNetworkSecurityConfigInjection
+		} else {
+			out, _, err = updateManifest(in, nil, nativeLibs{}, config)
+		}
+		if err != nil {
+			return fileType, err
+		}
+		write = func(w io.Writer) error {
+			_, err := w.Write(out)
+			return err
+		}
+	default:
+		a, err := openArchive(r, size)
+		if err != nil {
+			return fileType, err
+		}
+		switch fileType {
+		case inputTypeApks:
+			err = updateApks(a, config)
+		case inputTypeAab:
+			err = updateAab(a, config)
+		case inputTypeAar:
+			err = updateAar(a, config)
+		}
+		if err != nil {
+			return fileType, err
+		}
+		write = a.writeTo
+	}
+	return fileType, writeOutput(path, write)
+}
+
+// openInput opens the file at the given path or reads stdin if the path is "-".
+func openInput(path string) (io.ReaderAt, int64, func() error, error) {
+	if path == stdinPath {
+		// The zip's central directory is at the end, so the whole input has to be buffered.
+		data, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, 0, nil, fmt.Errorf("error reading stdin: %w", err)
+		}
+		return bytes.NewReader(data), int64(len(data)), func() error { return nil }, nil
+	}
+	f, err := os.Open(path)
 	if err != nil {
-		log.Fatalln("Failed executing aapt2:", err, string(out))
+		return nil, 0, nil, err
 	}
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, 0, nil, err
+	}
+	return f, stat.Size(), f.Close, nil
+}
 
-	updateManifestPbInZip(file.Name(), "AndroidManifest.xml", config)
+// resolveInputType detects the file type unless it was given explicitly.
+func resolveInputType(r io.ReaderAt, size int64, fileType inputType) (inputType, error) {
+	if fileType != inputTypeAuto {
+		return fileType, nil
+	}
+	fileType, err := detectInputType(r, size)
+	if err != nil {
+		return fileType, fmt.Errorf("failed to detect the file type: %w", err)
+	}
+	return fileType, nil
+}
 
-	out, err = exec.Command("aapt2", "convert", "-o", path, "--output-format", "binary", file.Name()).CombinedOutput()
+// readManifest returns the decoded AndroidManifest.xml of the file without modifying it. For APK sets, the manifest
+// of the base APK is returned.
+func readManifest(path string, fileType inputType) (*XmlNode, inputType, error) {
+	r, size, closeInput, err := openInput(path)
 	if err != nil {
-		log.Fatalln("Failed executing aapt2:", err, string(out))
+		return nil, fileType, err
 	}
+	defer closeInput()
+	if fileType, err = resolveInputType(r, size, fileType); err != nil {
+		return nil, fileType, err
+	}
+	manifest, err := readManifestFrom(r, size, fileType)
+	return manifest, fileType, err
 }
 
-func updateAab(path string, config *Config) {
-	updateManifestPbInZip(path, "base/manifest/AndroidManifest.xml", config)
+// readManifestFrom reads the manifest of the input with the resolved fileType.
+func readManifestFrom(r io.ReaderAt, size int64, fileType inputType) (*XmlNode, error) {
+	var data []byte
+	var err error
+	if fileType == inputTypeManifest {
+		data, err = ioutil.ReadAll(io.NewSectionReader(r, 0, size))
+	} else {
+		data, err = readManifestFromArchive(r, size, fileType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	format := detectXmlFormat(data)
+	manifest, err := decodeXml(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest (%s): %w", format, err)
+	}
+	return manifest, nil
 }
 
-func updateManifestPbInZip(path string, manifestPath string, config *Config) {
-	manifest, err := ioutil.TempFile(tmpDir, "AndroidManifest.*.xml")
+func readManifestFromArchive(r io.ReaderAt, size int64, fileType inputType) ([]byte, error) {
+	a, err := openArchive(r, size)
 	if err != nil {
-		log.Fatalln("Failed creating temp file:", err)
+		return nil, err
+	}
+	switch fileType {
+	case inputTypeAab:
+		return a.read("base/manifest/AndroidManifest.xml")
+	case inputTypeApks:
+		// Config splits only have a minimal manifest, so the base module's master split is preferred.
+		apkName := ""
+		for _, name := range a.names() {
+			if strings.HasSuffix(name, ".apk") && (apkName == "" || strings.HasSuffix(name, "/base-master.apk")) {
+				apkName = name
+			}
+		}
+		if apkName == "" {
+			return nil, errors.New("the APK set contains no APKs")
+		}
+		apk, err := a.read(apkName)
+		if err != nil {
+			return nil, err
+		}
+		return readManifestFromArchive(bytes.NewReader(apk), int64(len(apk)), inputTypeApk)
 	}
-	defer os.Remove(manifest.Name())
+	return a.read("AndroidManifest.xml")
+}
 
-	extractFromZip(path, manifestPath, manifest)
-	updateManifest(manifest.Name(), config)
-	addToZip(path, manifestPath, manifest)
+// writeOutput writes the result to stdout or replaces the file at the given path.
+func writeOutput(path string, write func(w io.Writer) error) error {
+	if path == stdinPath {
+		return write(os.Stdout)
+	}
+	stat, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	// The original file is still being read while writing, so the result goes into a temp file which replaces it.
+	f, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed creating temp file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("error writing file: %w", err)
+	}
+	if err := os.Chmod(f.Name(), stat.Mode().Perm()); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
 }
 
-func addToZip(zipPath string, name string, source *os.File) {
-	manifestDir, err := ioutil.TempDir(tmpDir, "*")
+// updateApk modifies a binary APK. Since aapt2 only works on files, the APK is converted via temp files.
+func updateApk(in []byte, config *Config) ([]byte, *XmlNode, error) {
+	if len(config.bundleConfigEdits) > 0 {
+		return nil, nil, errBundleConfigEdits
+	}
+
+	dir, err := ioutil.TempDir(tmpDir, "*")
 	if err != nil {
-		log.Fatalln("Failed creating temp dir:", err)
+		return nil, nil, fmt.Errorf("failed creating temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	binaryPath := filepath.Join(dir, "binary.apk")
+	protoPath := filepath.Join(dir, "proto.apk")
+	if err := ioutil.WriteFile(binaryPath, in, 0600); err != nil {
+		return nil, nil, err
 	}
-	defer os.RemoveAll(manifestDir)
 
-	tmpPath := path.Join(manifestDir, name)
-	os.MkdirAll(path.Dir(tmpPath), 0700)
-	f, err := os.Create(tmpPath)
+	out, err := exec.Command("aapt2", "convert", "-o", protoPath, "--output-format", "proto", binaryPath).CombinedOutput()
 	if err != nil {
-		log.Fatalln("Failed opening file:", err)
+		return nil, nil, fmt.Errorf("failed executing aapt2: %v %s", err, out)
 	}
-	defer f.Close()
-	source.Seek(0, 0)
-	io.Copy(f, source)
 
-	absZipPath, err := filepath.Abs(zipPath)
+	var manifest *XmlNode
+	err = updateArchiveFile(protoPath, func(a *archive) error {
+		if config.networkSecurityConfigXml != nil {
+			if err := injectNetworkSecurityConfig(a, "", config); err != nil {
+				return err
+			}
+		}
+		var err error
+		if manifest, err = updateManifestInArchive(a, "AndroidManifest.xml", resourceTableName, config); err != nil {
+			return err
+		}
+		if config.stripsResources() {
+			return stripResources(a, config)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out, err = exec.Command("aapt2", "convert", "-o", binaryPath, "--output-format", "binary", protoPath).CombinedOutput()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed executing aapt2: %v %s", err, out)
+	}
+	if config.signing != nil {
+		if err := signApk(binaryPath, config.signing, config); err != nil {
+			return nil, nil, err
+		}
+	}
+	result, err := ioutil.ReadFile(binaryPath)
+	return result, manifest, err
+}
+
+// updateArchiveFile lets update modify the zip file at the given path.
+func updateArchiveFile(path string, update func(a *archive) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	stat, err := f.Stat()
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
-	cmd := exec.Command("zip", absZipPath, name)
-	cmd.Dir = manifestDir
-	out, err := cmd.CombinedOutput()
+	a, err := openArchive(f, stat.Size())
 	if err != nil {
-		log.Fatalln("Failed executing zip:", err, string(out))
+		return err
 	}
+	if err := update(a); err != nil {
+		return err
+	}
+	return writeOutput(path, a.writeTo)
 }
 
-func extractFromZip(path string, name string, target *os.File) {
+func updateAab(a *archive, config *Config) error {
+	if config.networkSecurityConfigXml != nil {
+		if err := injectNetworkSecurityConfig(a, "base/", config); err != nil {
+			return err
+		}
+	}
+	if _, err := updateManifestInArchive(a, "base/manifest/AndroidManifest.xml", "base/"+resourceTableName, config); err != nil {
+		return err
+	}
+	if len(config.bundleConfigEdits) > 0 {
+		err := a.update(bundleConfigPath, func(data []byte) ([]byte, error) {
+			return updateBundleConfig(data, config)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if config.stripsResources() {
+		return stripResources(a, config)
+	}
+	return nil
+}
+
+// updateAar modifies the plain text AndroidManifest.xml of an Android library.
+func updateAar(a *archive, config *Config) error {
+	if len(config.bundleConfigEdits) > 0 {
+		return errBundleConfigEdits
+	}
+	if config.stripsResources() {
+		return errors.New("removing locales and densities is not supported for AAR files")
+	}
+	if config.networkSecurityConfigXml != nil {
+		if err := injectTextNetworkSecurityConfig(a, config); err != nil {
+			return err
+		}
+	}
+	_, err := updateManifestInArchive(a, "AndroidManifest.xml", "", config)
+	return err
+}
+
+// updateManifestInArchive updates the manifest at manifestPath. The app's resources are loaded from resourcesPath (if
+// any) when the config references resources.
+func updateManifestInArchive(a *archive, manifestPath string, resourcesPath string, config *Config) (*XmlNode, error) {
+	var resources []byte
+	if resourcesPath != "" && config.referencesResources() && a.file(resourcesPath) != nil {
+		var err error
+		if resources, err = a.read(resourcesPath); err != nil {
+			return nil, err
+		}
+	}
+	libs := readNativeLibs(a)
+	var manifest *XmlNode
+	err := a.update(manifestPath, func(data []byte) ([]byte, error) {
+		var out []byte
+		var err error
+		out, manifest, err = updateManifest(data, resources, libs, config)
+		return out, err
+	})
+	return manifest, err
+}
+
+// readFromZip returns the contents of the given file within the zip.
+func readFromZip(path string, name string) ([]byte, error) {
 	r, err := zip.OpenReader(path)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 	defer r.Close()
 
-	f := findFile(r, name)
+	f := findFile(&r.Reader, name)
 	if f == nil {
-		log.Fatalln(errors.New("file is missing"))
+		return nil, fmt.Errorf("%s is missing in %s", name, path)
 	}
-
 	innerFile, err := f.Open()
 	if err != nil {
-		log.Fatalln("Failed opening zip file's AndroidManifest.xml:", err)
+		return nil, err
 	}
 	defer innerFile.Close()
-	_, err = io.Copy(target, innerFile)
-	if err != nil {
-		log.Fatal(err)
-	}
+	return ioutil.ReadAll(innerFile)
 }
 
-func findFile(r *zip.ReadCloser, name string) *zip.File {
+func findFile(r *zip.Reader, name string) *zip.File {
 	for _, f := range r.File {
 		if f.Name != name {
 			continue
@@ -156,20 +758,63 @@ func findFile(r *zip.ReadCloser, name string) *zip.File {
 	return nil
 }
 
-func updateManifest(path string, config *Config) {
-	in, err := ioutil.ReadFile(path)
+// updateManifest applies the config to the manifest. The manifest can be in proto, binary or text XML format and is
+// returned in the same format. The optional resources.pb is used for resolving references to the app's resources.
+func updateManifest(in []byte, resources []byte, libs nativeLibs, config *Config) ([]byte, *XmlNode, error) {
+	format := detectXmlFormat(in)
+	var xmlNode *XmlNode
+	var textDoc *textXmlDocument
+	var err error
+	if format == xmlFormatText {
+		// Text manifests are only rewritten where they're edited, which keeps their comments and formatting.
+		if textDoc, err = parseTextXmlDocument(in); err == nil {
+			xmlNode = textDoc.root
+		}
+	} else {
+		xmlNode, err = decodeXml(in, format)
+	}
 	if err != nil {
-		log.Fatalln("Error reading file:", err)
+		return nil, nil, fmt.Errorf("failed to parse manifest (%s): %w", format, err)
+	}
+	editor := newManifestEditor(xmlNode, format != xmlFormatText, config)
+	editor.nativeLibs = libs
+	if resources != nil {
+		if err := editor.loadResources(resources); err != nil {
+			return nil, nil, fmt.Errorf("failed to load resources: %w", err)
+		}
+	}
+	if len(config.placeholders) > 0 {
+		if err := substitutePlaceholders(xmlNode, editor, config); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := editManifest(xmlNode, editor, config); err != nil {
+		return nil, nil, err
+	}
+	if !config.skipValidation {
+		if err := checkManifest(xmlNode, config); err != nil {
+			return nil, nil, err
+		}
 	}
 
-	xmlNode := &XmlNode{}
-	if err := proto.Unmarshal(in, xmlNode); err != nil {
-		log.Fatalln("Failed to parse manifest:", err)
+	if textDoc != nil {
+		return textDoc.format(xmlNode), xmlNode, nil
 	}
+	out, err := encodeXml(xmlNode, format)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error marshalling XML: %w", err)
+	}
+	return out, xmlNode, nil
+}
+
+// editManifest applies the config to the manifest. This works for compiled (proto) and plain text manifests.
+func editManifest(xmlNode *XmlNode, editor *manifestEditor, config *Config) error {
+	hasPackage := false
 	for _, attr := range xmlNode.GetElement().GetAttribute() {
 		if attr.GetNamespaceUri() == "" && attr.GetName() == "package" {
+			hasPackage = true
 			if config.packageName != "" {
-				fmt.Println("Changing packageName from", attr.Value, "to", config.packageName)
+				config.println("Changing packageName from", attr.Value, "to", config.packageName)
 				attr.Value = config.packageName
 			}
 		}
@@ -179,31 +824,60 @@ func updateManifest(path string, config *Config) {
 		switch attr.GetName() {
 		case versionCodeAttr:
 			if config.versionCode > 0 {
+				versionCode, err := editor.versionCode(xmlNode, config)
+				if err != nil {
+					return err
+				}
 				prim := attr.GetCompiledItem().GetPrim()
 				if x, ok := prim.GetOneofValue().(*Primitive_IntDecimalValue); ok {
-					fmt.Println("Changing versionCode from", x.IntDecimalValue, "to", config.versionCode)
-					x.IntDecimalValue = int32(config.versionCode)
+					config.println("Changing versionCode from", x.IntDecimalValue, "to", versionCode)
+					x.IntDecimalValue = versionCode
+				} else {
+					// Plain text manifests only have the value
+					config.println("Changing versionCode from", attr.Value, "to", versionCode)
 				}
 				// In AABs the value exists, but when using aapt2 to convert the binary manifest the value is gone
 				if attr.Value != "" {
-					attr.Value = fmt.Sprint(config.versionCode)
+					attr.Value = fmt.Sprint(versionCode)
 				}
 			}
 		case versionNameAttr:
 			if config.versionName != "" {
-				fmt.Println("Changing versionName from", attr.Value, "to", config.versionName)
+				config.println("Changing versionName from", attr.Value, "to", config.versionName)
 				attr.Value = config.versionName
 			}
 		}
 	}
+	// Library manifests built with a namespace in build.gradle don't have a package attribute.
+	if !hasPackage && config.packageName != "" {
+		config.println("Setting packageName to", config.packageName)
+		element := xmlNode.GetElement()
+		element.Attribute = append(element.Attribute, &XmlAttribute{Name: "package", Value: config.packageName})
+	}
 
-	// We use MarshalVT because it keeps the correct field ordering.
-	// With the standard Marshal function, Android Studio can't read the resulting proto file inside aab files. :-/
-	out, err := xmlNode.MarshalVT()
-	if err != nil {
-		log.Fatalln("Error marshalling XML:", err)
+	if err := editUsesSdk(xmlNode, editor, config); err != nil {
+		return err
+	}
+	if err := editPermissions(xmlNode, editor, config); err != nil {
+		return err
+	}
+	if err := editFeatures(xmlNode, editor, config); err != nil {
+		return err
+	}
+	if err := editComponents(xmlNode, editor, config); err != nil {
+		return err
+	}
+	if err := editIntentFilters(xmlNode, editor, config); err != nil {
+		return err
+	}
+	if err := editMetaData(xmlNode, editor, config); err != nil {
+		return err
+	}
+	if err := editNetworkSecurity(xmlNode, editor, config); err != nil {
+		return err
 	}
-	if err := ioutil.WriteFile(path, out, 0600); err != nil {
-		log.Fatalln("Error writing file:", err)
+	if err := editHardening(xmlNode, editor, config); err != nil {
+		return err
 	}
+	return editDebuggable(xmlNode, editor, config)
 }
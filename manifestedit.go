@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// manifestEditor changes attributes and elements in a way that fits the manifest's format: in compiled manifests,
+// android: attributes get their resource ID and a compiled value like aapt2 would produce them, while text manifests
+// only get the plain values.
+type manifestEditor struct {
+	compiled   bool
+	linker     *resourceLinker
+	nativeLibs nativeLibs
+}
+
+func newManifestEditor(xmlNode *XmlNode, compiled bool, config *Config) *manifestEditor {
+	var linker *resourceLinker
+	if config.linker != nil {
+		// The attribute and resource maps are only read, so they can be shared by concurrently processed files.
+		copied := *config.linker
+		linker = &copied
+	} else {
+		linker = newResourceLinker()
+	}
+	linker.packageName = getManifestAttribute(xmlNode, "", "package").GetValue()
+	return &manifestEditor{compiled: compiled, linker: linker}
+}
+
+// loadResources makes the app's resources available for references like @string/app_name. The maps are copied first
+// because they are shared with the other files.
+func (e *manifestEditor) loadResources(resourceTable []byte) error {
+	ids := make(map[string]uint32, len(e.linker.ids))
+	for name, id := range e.linker.ids {
+		ids[name] = id
+	}
+	names := make(map[uint32]string, len(e.linker.names))
+	for id, name := range e.linker.names {
+		names[id] = name
+	}
+	e.linker.ids, e.linker.names = ids, names
+	return e.linker.loadResourceTable(resourceTable)
+}
+
+// setAttribute sets the android: attribute of the element and returns the previous value or "" if it was missing.
+func (e *manifestEditor) setAttribute(element *XmlElement, name string, value string) (string, error) {
+	attr := findAttribute(element, namespace, name)
+	oldValue := ""
+	if attr == nil {
+		attr = &XmlAttribute{NamespaceUri: namespace, Name: name}
+		element.Attribute = append(element.Attribute, attr)
+	} else {
+		oldValue = attributeText(attr)
+	}
+	attr.Value = value
+	attr.CompiledItem = nil
+	if e.compiled {
+		if err := e.linker.compileAttribute(attr); err != nil {
+			return oldValue, fmt.Errorf("<%s>: %w", element.GetName(), err)
+		}
+	}
+	return oldValue, nil
+}
+
+// removeAttribute removes the android: attribute and reports whether it existed.
+func removeAttribute(element *XmlElement, name string) bool {
+	for i, attr := range element.Attribute {
+		if attr.GetNamespaceUri() == namespace && attr.GetName() == name {
+			element.Attribute = append(element.Attribute[:i], element.Attribute[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// addElement inserts a new child element at the given index or appends it if the index is negative.
+func addElement(parent *XmlElement, name string, index int) *XmlElement {
+	element := &XmlElement{Name: name}
+	node := &XmlNode{Node: &XmlNode_Element{Element: element}}
+	if index < 0 || index >= len(parent.Child) {
+		parent.Child = append(parent.Child, node)
+	} else {
+		parent.Child = append(parent.Child[:index], append([]*XmlNode{node}, parent.Child[index:]...)...)
+	}
+	return element
+}
+
+// insertionIndex returns the position for a new child element of the manifest: after the existing ones with the same
+// tag or otherwise before the <application>.
+func insertionIndex(root *XmlElement, tag string) int {
+	index := -1
+	for i, child := range root.Child {
+		switch child.GetElement().GetName() {
+		case tag:
+			index = i + 1
+		case "application":
+			if index < 0 {
+				return i
+			}
+		}
+	}
+	return index
+}
+
+// listElements prints the manifest's child elements with the given tags and their android: attributes.
+func listElements(w io.Writer, manifest *XmlNode, tags ...string) {
+	for _, child := range manifest.GetElement().GetChild() {
+		element := child.GetElement()
+		if !containsString(tags, element.GetName()) {
+			continue
+		}
+		line := element.GetName()
+		if name := findAttribute(element, namespace, "name"); name != nil {
+			line += " " + name.GetValue()
+		}
+		for _, attr := range element.GetAttribute() {
+			if attr.GetNamespaceUri() == namespace && attr.GetName() != "name" {
+				line += " " + attr.GetName() + "=" + attributeText(attr)
+			}
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// metaDataEdit sets the android:value or, for references, the android:resource of an <application> <meta-data>.
+type metaDataEdit struct {
+	name      string
+	value     string
+	reference bool
+}
+
+func parseMetaDataEdit(spec string, reference bool) (metaDataEdit, error) {
+	i := strings.IndexByte(spec, '=')
+	if i <= 0 {
+		return metaDataEdit{}, fmt.Errorf("invalid meta-data %q (expected name=value)", spec)
+	}
+	edit := metaDataEdit{name: spec[:i], value: spec[i+1:], reference: reference}
+	if reference && !isReference(edit.value) {
+		return edit, fmt.Errorf("invalid meta-data %q: %s is no resource reference like @string/name", spec, edit.value)
+	}
+	return edit, nil
+}
+
+// attrName returns the attribute which holds the value.
+func (e metaDataEdit) attrName() string {
+	if e.reference {
+		return "resource"
+	}
+	return "value"
+}
+
+// editMetaData removes, adds and updates the <meta-data> elements of the <application>. Values are compiled like
+// aapt2 does it, so e.g. numbers and booleans are stored as such, while references are resolved against the app's
+// resources.
+func editMetaData(xmlNode *XmlNode, editor *manifestEditor, config *Config) error {
+	if len(config.metaData) == 0 && len(config.removeMetaData) == 0 {
+		return nil
+	}
+	root := xmlNode.GetElement()
+	var application *XmlElement
+	if applications := childElements(root, "application"); len(applications) > 0 {
+		application = applications[0]
+	}
+
+	for _, name := range config.removeMetaData {
+		removed := false
+		if application != nil {
+			children := application.Child[:0]
+			for _, child := range application.Child {
+				element := child.GetElement()
+				if element.GetName() == "meta-data" && findAttribute(element, namespace, "name").GetValue() == name {
+					config.println("Removing <meta-data>", name)
+					removed = true
+					continue
+				}
+				children = append(children, child)
+			}
+			application.Child = children
+		}
+		if !removed {
+			config.println("Warning: meta-data", name, "not found")
+		}
+	}
+
+	for _, edit := range config.metaData {
+		if application == nil {
+			config.println("Adding <application>")
+			application = addElement(root, "application", -1)
+		}
+		var element *XmlElement
+		for _, existing := range childElements(application, "meta-data") {
+			if findAttribute(existing, namespace, "name").GetValue() == edit.name {
+				element = existing
+			}
+		}
+		if element == nil {
+			config.println("Adding <meta-data>", edit.name+"="+edit.value)
+			element = addElement(application, "meta-data", -1)
+			if _, err := editor.setAttribute(element, "name", edit.name); err != nil {
+				return err
+			}
+		} else {
+			oldValue := attributeText(findAttribute(element, namespace, "value"))
+			if oldValue == "" {
+				oldValue = attributeText(findAttribute(element, namespace, "resource"))
+			}
+			config.println("Changing <meta-data>", edit.name, "from", oldValue, "to", edit.value)
+		}
+		// A meta-data has either a value or a resource.
+		removeAttribute(element, "value")
+		removeAttribute(element, "resource")
+		if _, err := editor.setAttribute(element, edit.attrName(), edit.value); err != nil {
+			return fmt.Errorf("meta-data %s: %w", edit.name, err)
+		}
+	}
+	return nil
+}
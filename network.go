@@ -0,0 +1,235 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+// networkSecurityConfigName is the resource name of an injected network security config.
+const networkSecurityConfigName = "network_security_config"
+
+// removeValue as value of --network-security-config and --cleartext-traffic removes the attribute.
+const removeValue = "remove"
+
+// parseNetworkSecurityConfig accepts a reference like @xml/network_security_config, "remove" or the path of a text
+// XML file, which is returned for being injected into the app's resources.
+func parseNetworkSecurityConfig(value string) (string, []byte, error) {
+	if value == "" || value == removeValue || isReference(value) {
+		return value, nil, nil
+	}
+	data, err := ioutil.ReadFile(value)
+	if err != nil {
+		return "", nil, err
+	}
+	xmlNode, err := parseTextXml(data)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse %s: %w", value, err)
+	}
+	if xmlNode.GetElement().GetName() != "network-security-config" {
+		return "", nil, fmt.Errorf("%s is no network security config (the root element must be <network-security-config>)", value)
+	}
+	return "@xml/" + networkSecurityConfigName, data, nil
+}
+
+func parseCleartextTraffic(value string) (string, error) {
+	switch value {
+	case "", "true", "false", removeValue:
+		return value, nil
+	}
+	return "", fmt.Errorf("invalid cleartext traffic value %q (expected true, false or remove)", value)
+}
+
+// editNetworkSecurity sets or removes android:networkSecurityConfig and android:usesCleartextTraffic.
+func editNetworkSecurity(xmlNode *XmlNode, editor *manifestEditor, config *Config) error {
+	attrs := []struct{ name, value string }{
+		{"networkSecurityConfig", config.networkSecurityConfig},
+		{"usesCleartextTraffic", config.cleartextTraffic},
+	}
+	for _, attr := range attrs {
+		if attr.value == "" {
+			continue
+		}
+		applications := childElements(xmlNode.GetElement(), "application")
+		if attr.value == removeValue {
+			for _, application := range applications {
+				if removeAttribute(application, attr.name) {
+					config.println("Removing", attr.name)
+				}
+			}
+			continue
+		}
+		if len(applications) == 0 {
+			config.println("Adding <application>")
+			applications = append(applications, addElement(xmlNode.GetElement(), "application", -1))
+		}
+		oldValue, err := editor.setAttribute(applications[0], attr.name, attr.value)
+		if err != nil {
+			return err
+		}
+		if oldValue == "" {
+			config.println("Setting", attr.name, "to", attr.value)
+		} else {
+			config.println("Changing", attr.name, "from", oldValue, "to", attr.value)
+		}
+	}
+	return nil
+}
+
+// injectNetworkSecurityConfig adds the network security config as res/xml/network_security_config.xml to the
+// module (e.g. base/ in AABs) in proto XML format, together with its entry in the resource table. An existing
+// resource with the same name is replaced, including its qualified variants like res/xml-v24/.
+func injectNetworkSecurityConfig(a *archive, modulePrefix string, config *Config) error {
+	tableData, err := a.read(modulePrefix + resourceTableName)
+	if err != nil {
+		return err
+	}
+	table := &ResourceTable{}
+	if err := table.UnmarshalVT(tableData); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", modulePrefix+resourceTableName, err)
+	}
+	if len(table.Package) == 0 {
+		return errors.New("the resource table has no package")
+	}
+	pkg := table.Package[0]
+
+	var xmlType *Type
+	var maxTypeId uint32
+	for _, t := range pkg.Type {
+		if t.Name == "xml" {
+			xmlType = t
+		}
+		if id := t.GetTypeId().GetId(); id > maxTypeId {
+			maxTypeId = id
+		}
+	}
+	if xmlType == nil {
+		xmlType = &Type{TypeId: &TypeId{Id: maxTypeId + 1}, Name: "xml"}
+		pkg.Type = append(pkg.Type, xmlType)
+	}
+
+	path := "res/xml/" + networkSecurityConfigName + ".xml"
+	var entry *Entry
+	var maxEntryId uint32
+	for _, e := range xmlType.Entry {
+		if e.Name == networkSecurityConfigName {
+			entry = e
+		}
+		if id := e.GetEntryId().GetId(); id >= maxEntryId {
+			maxEntryId = id + 1
+		}
+	}
+	if entry == nil {
+		entry = &Entry{EntryId: &EntryId{Id: maxEntryId}, Name: networkSecurityConfigName}
+		xmlType.Entry = append(xmlType.Entry, entry)
+	} else {
+		for _, configValue := range entry.ConfigValue {
+			if file := configValue.GetValue().GetItem().GetFile(); file != nil {
+				a.remove(modulePrefix + file.Path)
+			}
+		}
+	}
+	entry.ConfigValue = []*ConfigValue{{
+		Config: &Configuration{},
+		Value: &Value{Value: &Value_Item{Item: &Item{Value: &Item_File{File: &FileReference{
+			Path: path,
+			Type: FileReference_PROTO_XML,
+		}}}}},
+	}}
+	if tableData, err = table.MarshalVT(); err != nil {
+		return err
+	}
+
+	xmlNode, err := parseTextXml(config.networkSecurityConfigXml)
+	if err != nil {
+		return err
+	}
+	// References like @raw/my_ca are resolved against the app's resources.
+	editor := newManifestEditor(xmlNode, true, config)
+	if err := editor.loadResources(tableData); err != nil {
+		return err
+	}
+	editor.linker.packageName = pkg.PackageName
+	if err := editor.linker.compileXml(xmlNode); err != nil {
+		return fmt.Errorf("failed to compile the network security config:\n%w", err)
+	}
+	compiled, err := xmlNode.MarshalVT()
+	if err != nil {
+		return err
+	}
+
+	config.println("Adding", modulePrefix+path)
+	a.add(modulePrefix+path, compiled)
+	a.add(modulePrefix+resourceTableName, tableData)
+	return nil
+}
+
+// injectTextNetworkSecurityConfig adds the network security config to the plain text resources of an AAR and its
+// symbol to the R.txt. Qualified variants of an existing resource like res/xml-v24/ are removed.
+func injectTextNetworkSecurityConfig(a *archive, config *Config) error {
+	path := "res/xml/" + networkSecurityConfigName + ".xml"
+	for _, name := range a.names() {
+		if strings.HasPrefix(name, "res/xml-") && strings.HasSuffix(name, "/"+networkSecurityConfigName+".xml") {
+			config.println("Removing", name)
+			a.remove(name)
+		}
+	}
+	config.println("Adding", path)
+	a.add(path, config.networkSecurityConfigXml)
+	if a.file(textSymbolsPath) == nil {
+		return nil
+	}
+	return a.update(textSymbolsPath, func(data []byte) ([]byte, error) {
+		return addTextSymbol(data, "xml", networkSecurityConfigName)
+	})
+}
+
+// textSymbolsPath is the list of an AAR's resources with lines like "int xml network_security_config 0x7f110000".
+const textSymbolsPath = "R.txt"
+
+// addTextSymbol adds an int symbol to the R.txt unless it exists. Libraries usually have the ID 0x0 for all symbols,
+// which is kept. Otherwise the symbol gets the next ID of its type, or of a new type.
+func addTextSymbol(data []byte, resType string, name string) ([]byte, error) {
+	var maxId, maxTypeId, maxTypeEntryId uint64
+	hasType := false
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		// Styleable entries are indexes into the styleable's attributes, not resource IDs.
+		if len(fields) != 4 || fields[0] != "int" || fields[1] == "styleable" {
+			continue
+		}
+		if fields[1] == resType && fields[2] == name {
+			return data, nil
+		}
+		id, err := strconv.ParseUint(fields[3], 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s line %q", textSymbolsPath, line)
+		}
+		if id > maxId {
+			maxId = id
+		}
+		if id&0xff0000 > maxTypeId {
+			maxTypeId = id & 0xff0000
+		}
+		if fields[1] == resType {
+			hasType = true
+			if id > maxTypeEntryId {
+				maxTypeEntryId = id
+			}
+		}
+	}
+	var id uint64
+	switch {
+	case maxId == 0:
+	case hasType:
+		id = maxTypeEntryId + 1
+	default:
+		id = maxId&0xff000000 | (maxTypeId + 0x10000)
+	}
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		data = append(data, '\n')
+	}
+	return append(data, fmt.Sprintf("int %s %s 0x%x\n", resType, name, id)...), nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestAddTextSymbol(t *testing.T) {
+	tests := []struct {
+		name    string
+		symbols string
+		want    string
+	}{
+		{
+			name:    "empty",
+			symbols: "",
+			want:    "int xml network_security_config 0x0\n",
+		},
+		{
+			name:    "library without IDs",
+			symbols: "int string app_name 0x0\nint xml file_paths 0x0",
+			want:    "int string app_name 0x0\nint xml file_paths 0x0\nint xml network_security_config 0x0\n",
+		},
+		{
+			name:    "existing type",
+			symbols: "int string app_name 0x7f100000\nint xml file_paths 0x7f110000\nint xml prefs 0x7f110001\n",
+			want:    "int string app_name 0x7f100000\nint xml file_paths 0x7f110000\nint xml prefs 0x7f110001\nint xml network_security_config 0x7f110002\n",
+		},
+		{
+			name:    "new type",
+			symbols: "int attr color 0x7f020000\nint string app_name 0x7f100000\nint styleable View_color 18\n",
+			want:    "int attr color 0x7f020000\nint string app_name 0x7f100000\nint styleable View_color 18\nint xml network_security_config 0x7f110000\n",
+		},
+		{
+			name:    "existing symbol",
+			symbols: "int xml network_security_config 0x7f110005\n",
+			want:    "int xml network_security_config 0x7f110005\n",
+		},
+		{
+			name:    "styleable arrays",
+			symbols: "int[] styleable View { 0x7f020000 }\nint string app_name 0x7f100000\n",
+			want:    "int[] styleable View { 0x7f020000 }\nint string app_name 0x7f100000\nint xml network_security_config 0x7f110000\n",
+		},
+	}
+	for _, test := range tests {
+		got, err := addTextSymbol([]byte(test.symbols), "xml", networkSecurityConfigName)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if string(got) != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+
+	if _, err := addTextSymbol([]byte("int xml file_paths 0xzz\n"), "xml", networkSecurityConfigName); err == nil {
+		t.Error("addTextSymbol() with an invalid ID succeeded, want an error")
+	}
+}
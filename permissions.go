@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// permissionTags are the elements which declare or request permissions.
+var permissionTags = []string{"uses-permission", "uses-permission-sdk-23", "permission"}
+
+// permissionSpec is a permission given as [tag:]name[,attr=value...], e.g.
+// android.permission.BLUETOOTH_SCAN,usesPermissionFlags=neverForLocation or
+// permission:com.some.app.permission.C2D,protectionLevel=signature.
+type permissionSpec struct {
+	tag   string
+	name  string
+	attrs []attributeSpec
+}
+
+// attributeSpec is an android: attribute given as name=value.
+type attributeSpec struct {
+	name  string
+	value string
+}
+
+func parsePermissionSpec(spec string, withAttrs bool) (permissionSpec, error) {
+	parts := strings.Split(spec, ",")
+	p := permissionSpec{name: strings.TrimSpace(parts[0])}
+	if i := strings.IndexByte(p.name, ':'); i >= 0 {
+		p.tag, p.name = p.name[:i], p.name[i+1:]
+		if !containsString(permissionTags, p.tag) {
+			return p, fmt.Errorf("invalid permission %q: unknown element <%s> (expected %s)", spec, p.tag, strings.Join(permissionTags, ", "))
+		}
+	}
+	if p.name == "" {
+		return p, fmt.Errorf("invalid permission %q: missing name", spec)
+	}
+	if len(parts) > 1 && !withAttrs {
+		return p, fmt.Errorf("invalid permission %q: attributes are not supported here", spec)
+	}
+	for _, part := range parts[1:] {
+		attr, err := parseAttributeSpec(part)
+		if err != nil {
+			return p, fmt.Errorf("invalid permission %q: %w", spec, err)
+		}
+		p.attrs = append(p.attrs, attr)
+	}
+	return p, nil
+}
+
+func parseAttributeSpec(spec string) (attributeSpec, error) {
+	i := strings.IndexByte(spec, '=')
+	if i <= 0 {
+		return attributeSpec{}, fmt.Errorf("invalid attribute %q (expected name=value)", spec)
+	}
+	return attributeSpec{strings.TrimPrefix(strings.TrimSpace(spec[:i]), "android:"), strings.TrimSpace(spec[i+1:])}, nil
+}
+
+// editPermissions removes and adds the configured permissions. Removing a permission without an element removes it
+// from <uses-permission> and <uses-permission-sdk-23>. Adding a permission which already exists updates its attributes.
+func editPermissions(xmlNode *XmlNode, editor *manifestEditor, config *Config) error {
+	root := xmlNode.GetElement()
+	for _, p := range config.removePermissions {
+		removed := false
+		children := root.Child[:0]
+		for _, child := range root.Child {
+			element := child.GetElement()
+			if p.matches(element) {
+				config.println("Removing", "<"+element.GetName()+">", p.name)
+				removed = true
+				continue
+			}
+			children = append(children, child)
+		}
+		root.Child = children
+		if !removed {
+			config.println("Warning: permission", p.name, "not found")
+		}
+	}
+
+	for _, p := range config.addPermissions {
+		tag := p.tag
+		if tag == "" {
+			tag = "uses-permission"
+		}
+		var element *XmlElement
+		for _, existing := range childElements(root, tag) {
+			if findAttribute(existing, namespace, "name").GetValue() == p.name {
+				element = existing
+			}
+		}
+		if element == nil {
+			config.println("Adding", "<"+tag+">", p.name)
+			element = addElement(root, tag, insertionIndex(root, tag))
+			if _, err := editor.setAttribute(element, "name", p.name); err != nil {
+				return err
+			}
+		} else if len(p.attrs) > 0 {
+			config.println("Updating", "<"+tag+">", p.name)
+		}
+		for _, attr := range p.attrs {
+			if _, err := editor.setAttribute(element, attr.name, attr.value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p permissionSpec) matches(element *XmlElement) bool {
+	if element == nil || findAttribute(element, namespace, "name").GetValue() != p.name {
+		return false
+	}
+	if p.tag == "" {
+		return element.GetName() == "uses-permission" || element.GetName() == "uses-permission-sdk-23"
+	}
+	return element.GetName() == p.tag
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// placeholderPattern matches Gradle manifest placeholders like ${applicationId}.
+var placeholderPattern = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// parsePlaceholder parses a key=value expression.
+func parsePlaceholder(expr string, placeholders map[string]string) error {
+	i := strings.IndexByte(expr, '=')
+	if i <= 0 {
+		return fmt.Errorf("invalid placeholder %q (expected key=value)", expr)
+	}
+	placeholders[expr[:i]] = expr[i+1:]
+	return nil
+}
+
+// substitutePlaceholders replaces the placeholders in all attribute values and text nodes and warns about the
+// unresolved ones. In compiled manifests, the changed android: attributes are compiled again, so e.g. a substituted
+// number becomes an integer like aapt2 would have done it.
+func substitutePlaceholders(xmlNode *XmlNode, editor *manifestEditor, config *Config) error {
+	linker := editor.linker
+	var errs []string
+	substitute := func(value string, path string) (string, bool) {
+		if !strings.Contains(value, "${") {
+			return value, false
+		}
+		result := placeholderPattern.ReplaceAllStringFunc(value, func(placeholder string) string {
+			key := placeholder[2 : len(placeholder)-1]
+			if replacement, ok := config.placeholders[key]; ok {
+				return replacement
+			}
+			config.println("Warning: unresolved placeholder", placeholder, "in", path)
+			return placeholder
+		})
+		return result, result != value
+	}
+
+	var walk func(node *XmlNode, parentPath string)
+	walk = func(node *XmlNode, parentPath string) {
+		element := node.GetElement()
+		if element == nil {
+			if text, changed := substitute(node.GetText(), parentPath); changed {
+				node.Node = &XmlNode_Text{Text: text}
+			}
+			return
+		}
+		path := elementPath(parentPath, element)
+		for _, attr := range element.Attribute {
+			value, changed := substitute(attr.Value, path)
+			if !changed {
+				continue
+			}
+			config.println("Replacing placeholders in", path+":", attr.Value, "->", value)
+			attr.Value = value
+			if !editor.compiled || attr.NamespaceUri == namespace && linker.attrs[attr.Name] == nil {
+				// Unknown attributes keep their resource ID and stay plain strings.
+				continue
+			}
+			if attr.ResourceId != 0 || attr.CompiledItem != nil || isReference(value) {
+				attr.CompiledItem = nil
+				if err := linker.compileAttribute(attr); err != nil {
+					errs = append(errs, fmt.Sprintf("%s: %v", path, err))
+				}
+			}
+		}
+		for _, child := range element.Child {
+			walk(child, path)
+		}
+	}
+	walk(xmlNode, "")
+	if len(errs) > 0 {
+		return errors.New("failed to compile substituted placeholders:\n  " + strings.Join(errs, "\n  "))
+	}
+	return nil
+}
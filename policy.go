@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// policy are the constraints which a release artifact's manifest has to fulfill.
+type policy struct {
+	// Package is the expected package name.
+	Package string `yaml:"package"`
+	// MinTargetSdk is the lowest allowed targetSdkVersion.
+	MinTargetSdk int64 `yaml:"minTargetSdk"`
+	// AllowedPermissions are the only permissions the app may request. An empty list forbids all permissions while a
+	// missing list allows all permissions.
+	AllowedPermissions []string `yaml:"allowedPermissions"`
+	// ForbiddenAttributes must not be set (to the given value).
+	ForbiddenAttributes []forbiddenAttribute `yaml:"forbiddenAttributes"`
+}
+
+// forbiddenAttribute is an attribute like android:debuggable on the element at the path (e.g. application/activity)
+// below the manifest element. If Value is empty, any value is forbidden.
+type forbiddenAttribute struct {
+	Element   string `yaml:"element"`
+	Attribute string `yaml:"attribute"`
+	Value     string `yaml:"value"`
+}
+
+func loadPolicy(path string) (*policy, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	p := &policy{}
+	if err := decoder.Decode(p); err != nil && err != io.EOF {
+		return nil, err
+	}
+	for _, attr := range p.ForbiddenAttributes {
+		if attr.Attribute == "" {
+			return nil, fmt.Errorf("forbiddenAttributes entry without attribute")
+		}
+	}
+	return p, nil
+}
+
+// checkPolicy returns the policy violations of the manifest.
+func checkPolicy(manifest *XmlNode, p *policy) []manifestIssue {
+	var issues []manifestIssue
+	report := func(rule string, path string, format string, a ...interface{}) {
+		issues = append(issues, manifestIssue{rule, severityError, path, fmt.Sprintf(format, a...)})
+	}
+	root := manifest.GetElement()
+	rootPath := "/" + root.GetName()
+
+	if p.Package != "" {
+		if packageName := findAttribute(root, "", "package").GetValue(); packageName != p.Package {
+			report("policy-package", rootPath, "package is %q, but %q is expected", packageName, p.Package)
+		}
+	}
+
+	if p.MinTargetSdk > 0 {
+		var targetSdk int64
+		hasTargetSdk := false
+		for _, usesSdk := range childElements(root, "uses-sdk") {
+			targetSdk, hasTargetSdk = attributeInt(findAttribute(usesSdk, namespace, "targetSdkVersion"))
+		}
+		if !hasTargetSdk {
+			report("policy-target-sdk", rootPath+"/uses-sdk", "targetSdkVersion is missing, but at least %d is required", p.MinTargetSdk)
+		} else if targetSdk < p.MinTargetSdk {
+			report("policy-target-sdk", rootPath+"/uses-sdk", "targetSdkVersion is %d, but at least %d is required", targetSdk, p.MinTargetSdk)
+		}
+	}
+
+	if p.AllowedPermissions != nil {
+		allowed := map[string]bool{}
+		for _, permission := range p.AllowedPermissions {
+			allowed[permission] = true
+		}
+		for _, tag := range []string{"uses-permission", "uses-permission-sdk-23"} {
+			for _, element := range childElements(root, tag) {
+				if name := findAttribute(element, namespace, "name").GetValue(); !allowed[name] {
+					report("policy-permission", elementPath(rootPath, element), "%s is not an allowed permission", name)
+				}
+			}
+		}
+	}
+
+	for _, forbidden := range p.ForbiddenAttributes {
+		namespaceUri, name := splitAttributeName(forbidden.Attribute)
+		for _, match := range findElements(root, rootPath, forbidden.Element) {
+			attr := findAttribute(match.element, namespaceUri, name)
+			if attr == nil {
+				continue
+			}
+			if value := attributeText(attr); forbidden.Value == "" || value == forbidden.Value {
+				report("policy-attribute", match.path, "%s=%q is forbidden", forbidden.Attribute, value)
+			}
+		}
+	}
+	return issues
+}
+
+// splitAttributeName splits names like android:debuggable into the namespace URI and the name.
+func splitAttributeName(qualifiedName string) (string, string) {
+	prefix := ""
+	name := qualifiedName
+	if i := strings.IndexByte(qualifiedName, ':'); i >= 0 {
+		prefix, name = qualifiedName[:i], qualifiedName[i+1:]
+	}
+	switch prefix {
+	case "":
+		return "", name
+	case "android":
+		return namespace, name
+	case "tools":
+		return toolsNamespace, name
+	case "app":
+		return autoNamespace, name
+	}
+	return prefix, name
+}
+
+// attributeText returns the attribute's value as it would appear in a text XML file.
+func attributeText(attr *XmlAttribute) string {
+	if attr.GetValue() != "" || attr.GetCompiledItem() == nil {
+		return attr.GetValue()
+	}
+	return formatItem(attr.GetCompiledItem())
+}
+
+type elementMatch struct {
+	element *XmlElement
+	path    string
+}
+
+// findElements returns the elements at the slash-separated path like application/activity below the root. The path
+// may start with the root element's name and an empty path matches the root.
+func findElements(root *XmlElement, rootPath string, path string) []elementMatch {
+	names := strings.Split(strings.Trim(path, "/"), "/")
+	if names[0] == root.GetName() || names[0] == "" {
+		names = names[1:]
+	}
+	matches := []elementMatch{{root, rootPath}}
+	for _, name := range names {
+		var next []elementMatch
+		for _, match := range matches {
+			for _, child := range childElements(match.element, name) {
+				next = append(next, elementMatch{child, elementPath(match.path, child)})
+			}
+		}
+		matches = next
+	}
+	return matches
+}
+
+// checkCommand evaluates a policy against the manifests of the given files.
+func checkCommand(args []string) {
+	flags := commandFlags("check", "--policy policy.yaml [flags] file...")
+	policyPath := flags.String("policy", "", "The YAML file with the policy")
+	format := flags.String("format", "text", "The output format: text, json or sarif")
+	typeName := flags.String("type", "", "The file type: apk, apks, aab, aar or manifest (detected from the contents by default)")
+	flags.Parse(args)
+	if flags.NArg() == 0 || *policyPath == "" {
+		flags.Usage()
+		os.Exit(2)
+	}
+	write, err := lintWriter(*format)
+	if err != nil {
+		fmt.Fprintln(flags.Output(), "Error:", err)
+		os.Exit(2)
+	}
+	fileType := inputTypeAuto
+	if *typeName != "" {
+		if fileType, err = parseInputType(*typeName); err != nil {
+			fmt.Fprintln(flags.Output(), "Error:", err)
+			os.Exit(2)
+		}
+	}
+	p, err := loadPolicy(*policyPath)
+	if err != nil {
+		log.Fatalln("Failed to load policy", *policyPath+":", err)
+	}
+	paths, err := expandPaths(flags.Args())
+	if err != nil {
+		fmt.Fprintln(flags.Output(), "Error:", err)
+		os.Exit(2)
+	}
+
+	var results []lintResult
+	violations := 0
+	for _, path := range paths {
+		manifest, _, err := readManifest(path, fileType)
+		if err != nil {
+			log.Fatalln("Failed to read", path+":", err)
+		}
+		issues := checkPolicy(manifest, p)
+		violations += len(issues)
+		results = append(results, lintResult{path, issues})
+	}
+	if err := write(os.Stdout, results); err != nil {
+		log.Fatalln("Error:", err)
+	}
+	if violations > 0 {
+		fmt.Fprintf(os.Stderr, "%d policy violation(s)\n", violations)
+		os.Exit(1)
+	}
+}
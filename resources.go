@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const resourceTableName = "resources.pb"
+
+// parseLocales parses a comma-separated list of locales given as qualifiers ("de-rDE", "b+sr+Latn") or BCP-47 tags
+// ("de-DE").
+func parseLocales(list string) ([]string, error) {
+	var locales []string
+	for _, item := range splitList(list) {
+		config, err := parseConfiguration(item)
+		if (err != nil || config.Locale == "") && !strings.HasPrefix(item, "b+") {
+			config, err = parseConfiguration("b+" + strings.ReplaceAll(item, "-", "+"))
+		}
+		if err != nil || config.Locale == "" || formatConfiguration(config) != formatConfiguration(&Configuration{Locale: config.Locale}) {
+			return nil, fmt.Errorf("invalid locale %q", item)
+		}
+		locales = append(locales, config.Locale)
+	}
+	return locales, nil
+}
+
+// parseDensities parses a comma-separated list of densities like "xxhdpi,420dpi".
+func parseDensities(list string) ([]uint32, error) {
+	var result []uint32
+	for _, item := range splitList(list) {
+		config, err := parseConfiguration(item)
+		if err != nil || config.Density == 0 || formatConfiguration(config) != formatConfiguration(&Configuration{Density: config.Density}) {
+			return nil, fmt.Errorf("invalid density %q", item)
+		}
+		result = append(result, config.Density)
+	}
+	return result, nil
+}
+
+func splitList(list string) []string {
+	var result []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// stripResources removes all resource values for locales and densities which aren't listed in the config from every
+// resource table in the (proto format) zip and deletes the files which are no longer referenced.
+func stripResources(a *archive, config *Config) error {
+	sizes := map[string]int64{}
+	var tables []string
+	for _, f := range a.reader.File {
+		sizes[f.Name] = int64(f.CompressedSize64)
+		// APKs have the table at the root, AABs have one per module.
+		if f.Name == resourceTableName || (strings.Count(f.Name, "/") == 1 && filepath.Base(f.Name) == resourceTableName) {
+			tables = append(tables, f.Name)
+		}
+	}
+
+	var removedFiles []string
+	removedValues := 0
+	saved := int64(0)
+	for _, table := range tables {
+		prefix := strings.TrimSuffix(table, resourceTableName)
+		var orphans []string
+		err := a.update(table, func(data []byte) ([]byte, error) {
+			out, tableOrphans, removed, err := stripResourceTable(data, config)
+			orphans = tableOrphans
+			removedValues += removed
+			saved += int64(len(data) - len(out))
+			return out, err
+		})
+		if err != nil {
+			return err
+		}
+		for _, orphan := range orphans {
+			if size, ok := sizes[prefix+orphan]; ok {
+				removedFiles = append(removedFiles, prefix+orphan)
+				saved += size
+			}
+		}
+	}
+	a.remove(removedFiles...)
+	config.printf("Removed %d resource values and %d files, saving %s\n", removedValues, len(removedFiles), formatSize(saved))
+	return nil
+}
+
+// stripResourceTable filters the resource table and returns the filtered table, the paths of all files which are no
+// longer referenced and the number of removed values.
+func stripResourceTable(in []byte, config *Config) ([]byte, []string, int, error) {
+	table := &ResourceTable{}
+	if err := table.UnmarshalVT(in); err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to parse resource table: %w", err)
+	}
+
+	removed := 0
+	keptFiles := map[string]bool{}
+	removedFiles := map[string]bool{}
+	for _, pkg := range table.Package {
+		for _, typ := range pkg.Type {
+			for _, entry := range typ.Entry {
+				var kept []*ConfigValue
+				for _, value := range entry.ConfigValue {
+					if keepConfigValue(value, entry.ConfigValue, config) {
+						kept = append(kept, value)
+						if file := value.GetValue().GetItem().GetFile(); file != nil {
+							keptFiles[file.Path] = true
+						}
+					} else {
+						removed++
+						if file := value.GetValue().GetItem().GetFile(); file != nil {
+							removedFiles[file.Path] = true
+						}
+					}
+				}
+				entry.ConfigValue = kept
+			}
+		}
+	}
+
+	var orphans []string
+	for file := range removedFiles {
+		if !keptFiles[file] {
+			orphans = append(orphans, file)
+		}
+	}
+	sort.Strings(orphans)
+
+	out, err := table.MarshalVT()
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("error marshalling resource table: %w", err)
+	}
+	return out, orphans, removed, nil
+}
+
+// keepConfigValue decides whether the value should be kept. Values for the default locale and density are always
+// kept. Translations are only removed if the entry has a default-locale value, which resolves the resource instead.
+// If none of an entry's densities (for an otherwise identical config) is kept, the density-independent value
+// resolves the resource on every device. Only if there is none, the highest density is kept as the fallback.
+func keepConfigValue(value *ConfigValue, values []*ConfigValue, config *Config) bool {
+	c := value.GetConfig()
+	if c.GetLocale() != "" && len(config.keepLocales) > 0 && !matchesLocale(c.GetLocale(), config.keepLocales) && hasDefaultLocale(values) {
+		return false
+	}
+	if !isDensitySpecific(c.GetDensity()) || len(config.keepDensities) == 0 || containsDensity(config.keepDensities, c.GetDensity()) {
+		return true
+	}
+	key := densityIndependentKey(c)
+	highest := uint32(0)
+	for _, other := range values {
+		density := other.GetConfig().GetDensity()
+		if densityIndependentKey(other.GetConfig()) != key {
+			continue
+		}
+		if !isDensitySpecific(density) || containsDensity(config.keepDensities, density) {
+			return false
+		}
+		if density > highest {
+			highest = density
+		}
+	}
+	return c.GetDensity() == highest
+}
+
+// matchesLocale checks if the locale is kept. Keeping a language ("de") keeps all of its regions ("de-AT", "de-DE").
+// Keeping a region keeps its parent locales ("de-AT" keeps "de", "sr-Latn-RS" keeps "sr-Latn" and "sr"), which
+// devices fall back to for the resources that aren't translated for the region.
+func matchesLocale(locale string, keep []string) bool {
+	locale = strings.ToLower(locale)
+	for _, k := range keep {
+		k = strings.ToLower(k)
+		if locale == k || strings.HasPrefix(locale, k+"-") || strings.HasPrefix(k, locale+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDefaultLocale checks if any of the values is for the default locale.
+func hasDefaultLocale(values []*ConfigValue) bool {
+	for _, value := range values {
+		if value.GetConfig().GetLocale() == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func isDensitySpecific(density uint32) bool {
+	return density != 0 && density != densityAny && density != densityNone
+}
+
+func containsDensity(densities []uint32, density uint32) bool {
+	for _, d := range densities {
+		if d == density {
+			return true
+		}
+	}
+	return false
+}
+
+func densityIndependentKey(config *Configuration) string {
+	c := proto.Clone(config).(*Configuration)
+	c.Density = 0
+	return formatConfiguration(c)
+}
+
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(size)/(1<<10))
+	}
+	return fmt.Sprintf("%d bytes", size)
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func configValue(qualifiers string) *ConfigValue {
+	config, err := parseConfiguration(qualifiers)
+	if err != nil {
+		panic(err)
+	}
+	return &ConfigValue{Config: config}
+}
+
+func fileValue(qualifiers string, path string) *ConfigValue {
+	value := configValue(qualifiers)
+	value.Value = &Value{Value: &Value_Item{Item: &Item{Value: &Item_File{File: &FileReference{Path: path}}}}}
+	return value
+}
+
+func TestKeepConfigValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *Config
+		values []string
+		kept   []string
+	}{
+		{
+			name:   "keep a language",
+			config: &Config{keepLocales: []string{"de"}},
+			values: []string{"", "de", "de-rAT", "fr", "b+sr+Latn"},
+			kept:   []string{"", "de", "de-rAT"},
+		},
+		{
+			name:   "keep a region and its parents",
+			config: &Config{keepLocales: []string{"de-AT", "sr-Latn-RS"}},
+			values: []string{"", "de", "de-rAT", "de-rDE", "sr", "b+sr+Latn", "b+sr+Latn+RS", "b+sr+Cyrl"},
+			kept:   []string{"", "de", "de-rAT", "sr", "b+sr+Latn", "b+sr+Latn+RS"},
+		},
+		{
+			name:   "translations without a default locale",
+			config: &Config{keepLocales: []string{"de"}},
+			values: []string{"fr", "it"},
+			kept:   []string{"fr", "it"},
+		},
+		{
+			name:   "keep a density",
+			config: &Config{keepDensities: []uint32{480}},
+			values: []string{"", "mdpi", "xxhdpi", "xxxhdpi", "anydpi-v26"},
+			kept:   []string{"", "xxhdpi", "anydpi-v26"},
+		},
+		{
+			name:   "density-independent fallback",
+			config: &Config{keepDensities: []uint32{480}},
+			values: []string{"", "mdpi", "xhdpi"},
+			kept:   []string{""},
+		},
+		{
+			name:   "highest density fallback",
+			config: &Config{keepDensities: []uint32{480}},
+			values: []string{"mdpi", "xhdpi", "night-mdpi", "night-xxhdpi"},
+			kept:   []string{"xhdpi", "night-xxhdpi"},
+		},
+		{
+			name:   "locales and densities",
+			config: &Config{keepLocales: []string{"de"}, keepDensities: []uint32{480}},
+			values: []string{"mdpi", "xxhdpi", "fr-xxhdpi", "de-mdpi"},
+			kept:   []string{"xxhdpi", "de-mdpi"},
+		},
+	}
+	for _, test := range tests {
+		var values []*ConfigValue
+		for _, qualifiers := range test.values {
+			values = append(values, configValue(qualifiers))
+		}
+		var kept []string
+		for i, value := range values {
+			if keepConfigValue(value, values, test.config) {
+				kept = append(kept, test.values[i])
+			}
+		}
+		if !reflect.DeepEqual(kept, test.kept) {
+			t.Errorf("%s: kept %q, want %q", test.name, kept, test.kept)
+		}
+	}
+}
+
+func TestStripResourceTable(t *testing.T) {
+	table := &ResourceTable{Package: []*Package{{Type: []*Type{{Name: "drawable", Entry: []*Entry{
+		{Name: "icon", ConfigValue: []*ConfigValue{
+			fileValue("mdpi", "res/drawable-mdpi/icon.png"),
+			fileValue("xxhdpi", "res/drawable-xxhdpi/icon.png"),
+		}},
+		// The file is shared with a kept value.
+		{Name: "logo", ConfigValue: []*ConfigValue{
+			fileValue("", "res/drawable/logo.xml"),
+			fileValue("mdpi", "res/drawable/logo.xml"),
+			fileValue("de", "res/drawable-de/logo.xml"),
+		}},
+	}}}}}}
+	data, err := table.MarshalVT()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, orphans, removed, err := stripResourceTable(data, &Config{keepLocales: []string{"fr"}, keepDensities: []uint32{480}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 3 {
+		t.Errorf("removed %d values, want 3", removed)
+	}
+	wantOrphans := []string{"res/drawable-de/logo.xml", "res/drawable-mdpi/icon.png"}
+	if !reflect.DeepEqual(orphans, wantOrphans) {
+		t.Errorf("orphans = %q, want %q", orphans, wantOrphans)
+	}
+
+	stripped := &ResourceTable{}
+	if err := stripped.UnmarshalVT(out); err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, entry := range stripped.Package[0].Type[0].Entry {
+		for _, value := range entry.ConfigValue {
+			paths = append(paths, value.GetValue().GetItem().GetFile().GetPath())
+		}
+	}
+	wantPaths := []string{"res/drawable-xxhdpi/icon.png", "res/drawable/logo.xml"}
+	if !reflect.DeepEqual(paths, wantPaths) {
+		t.Errorf("kept %q, want %q", paths, wantPaths)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// sdkCodenamePattern matches preview SDK codenames like VanillaIceCream, which aapt2 keeps as strings.
+var sdkCodenamePattern = regexp.MustCompile(`^[A-Z][A-Za-z]*$`)
+
+// parseSdkVersion checks that the value is an API level or, if allowed, a preview codename.
+func parseSdkVersion(value string, allowCodename bool) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if level, err := strconv.Atoi(value); err == nil {
+		if level < 1 {
+			return "", fmt.Errorf("invalid SDK version %d", level)
+		}
+		return value, nil
+	}
+	if allowCodename && sdkCodenamePattern.MatchString(value) {
+		return value, nil
+	}
+	if allowCodename {
+		return "", fmt.Errorf("invalid SDK version %q (expected an API level or a codename like VanillaIceCream)", value)
+	}
+	return "", fmt.Errorf("invalid SDK version %q (expected an API level)", value)
+}
+
+// editUsesSdk sets the configured SDK versions, creating the <uses-sdk> element if necessary.
+func editUsesSdk(xmlNode *XmlNode, editor *manifestEditor, config *Config) error {
+	versions := []struct{ name, value string }{
+		{"minSdkVersion", config.minSdk},
+		{"targetSdkVersion", config.targetSdk},
+		{"maxSdkVersion", config.maxSdk},
+	}
+	var usesSdk *XmlElement
+	for _, version := range versions {
+		if version.value == "" {
+			continue
+		}
+		if usesSdk == nil {
+			root := xmlNode.GetElement()
+			if elements := childElements(root, "uses-sdk"); len(elements) > 0 {
+				usesSdk = elements[0]
+			} else {
+				config.println("Adding <uses-sdk>")
+				usesSdk = addElement(root, "uses-sdk", 0)
+			}
+		}
+		oldValue, err := editor.setAttribute(usesSdk, version.name, version.value)
+		if err != nil {
+			return err
+		}
+		if oldValue == "" {
+			config.println("Setting", version.name, "to", version.value)
+		} else {
+			config.println("Changing", version.name, "from", oldValue, "to", version.value)
+		}
+	}
+	return nil
+}
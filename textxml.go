@@ -0,0 +1,479 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const xmlHeader = `<?xml version="1.0" encoding="utf-8"?>` + "\n"
+
+// textXmlDocument is a parsed text XML file which remembers the source of each element. Only the edited elements are
+// rewritten when formatting it, so comments, processing instructions and the layout of everything else are kept.
+type textXmlDocument struct {
+	data    []byte
+	root    *XmlNode
+	sources map[*XmlElement]*textXmlSource
+	// indentUnit is the file's indentation per level, used for elements which had no children.
+	indentUnit string
+}
+
+// textXmlSource is the source of an element as it was parsed.
+type textXmlSource struct {
+	// start and end enclose the whole element, tagEnd is the end of the start tag.
+	start, tagEnd, end int
+	// head is the start tag up to the first attribute, tail the rest after the last attribute, e.g. " />".
+	head, tail  []byte
+	attrs       []textXmlAttr
+	selfClosing bool
+	indent      string
+	children    []*XmlElement
+	text        string
+}
+
+// textXmlAttr is an attribute or namespace declaration of a start tag, including the whitespace in front of it.
+type textXmlAttr struct {
+	raw       []byte
+	name      []byte
+	attr      *XmlAttribute
+	namespace *XmlNamespace
+	value     string
+}
+
+// parseTextXml parses a plain text XML document into an XmlNode tree. Whitespace-only text and comments are dropped,
+// just like aapt2 does when compiling XML files.
+func parseTextXml(data []byte) (*XmlNode, error) {
+	doc, err := parseTextXmlDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	return doc.root, nil
+}
+
+func parseTextXmlDocument(data []byte) (*textXmlDocument, error) {
+	doc := &textXmlDocument{data: data, sources: map[*XmlElement]*textXmlSource{}}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var root *XmlNode
+	var stack []*XmlElement
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			element := &XmlElement{NamespaceUri: t.Name.Space, Name: t.Name.Local}
+			src := &textXmlSource{start: int(offset), tagEnd: int(decoder.InputOffset()), indent: lineIndent(data, int(offset))}
+			src.head, src.attrs, src.tail = splitStartTag(data[src.start:src.tagEnd])
+			src.selfClosing = bytes.HasSuffix(src.tail, []byte("/>"))
+			if len(src.attrs) != len(t.Attr) {
+				return nil, fmt.Errorf("failed to parse the start tag <%s> in line %d", t.Name.Local, bytes.Count(data[:offset], []byte("\n"))+1)
+			}
+			doc.sources[element] = src
+			for i, attr := range t.Attr {
+				src.attrs[i].value = attr.Value
+				switch {
+				case attr.Name.Space == "xmlns":
+					src.attrs[i].namespace = &XmlNamespace{Prefix: attr.Name.Local, Uri: attr.Value}
+					element.NamespaceDeclaration = append(element.NamespaceDeclaration, src.attrs[i].namespace)
+				case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+					src.attrs[i].namespace = &XmlNamespace{Uri: attr.Value}
+					element.NamespaceDeclaration = append(element.NamespaceDeclaration, src.attrs[i].namespace)
+				default:
+					src.attrs[i].attr = &XmlAttribute{
+						NamespaceUri: attr.Name.Space,
+						Name:         attr.Name.Local,
+						Value:        attr.Value,
+					}
+					element.Attribute = append(element.Attribute, src.attrs[i].attr)
+				}
+			}
+			node := &XmlNode{
+				Node:   &XmlNode_Element{Element: element},
+				Source: &SourcePosition{LineNumber: uint32(bytes.Count(data[:offset], []byte("\n")) + 1)},
+			}
+			if len(stack) == 0 {
+				if root != nil {
+					return nil, fmt.Errorf("multiple root elements")
+				}
+				root = node
+			} else {
+				parent := stack[len(stack)-1]
+				parent.Child = append(parent.Child, node)
+				doc.sources[parent].children = append(doc.sources[parent].children, element)
+			}
+			stack = append(stack, element)
+		case xml.EndElement:
+			doc.sources[stack[len(stack)-1]].end = int(decoder.InputOffset())
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) == 0 || len(bytes.TrimSpace(t)) == 0 {
+				continue
+			}
+			parent := stack[len(stack)-1]
+			parent.Child = append(parent.Child, &XmlNode{Node: &XmlNode_Text{Text: string(t)}})
+			doc.sources[parent].text += string(t)
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("missing root element")
+	}
+	doc.root = root
+	doc.indentUnit = "    "
+	if src := doc.sources[root.GetElement()]; len(src.children) > 0 {
+		if unit := strings.TrimPrefix(doc.sources[src.children[0]].indent, src.indent); unit != "" {
+			doc.indentUnit = unit
+		}
+	}
+	return doc, nil
+}
+
+// lineIndent returns the whitespace in front of offset if nothing else precedes it in its line.
+func lineIndent(data []byte, offset int) string {
+	lineStart := bytes.LastIndexByte(data[:offset], '\n') + 1
+	indent := data[lineStart:offset]
+	if len(bytes.TrimLeft(indent, " \t")) > 0 {
+		return ""
+	}
+	return string(indent)
+}
+
+// splitStartTag splits a start tag like <uses-sdk android:minSdkVersion="21" /> into "<uses-sdk", its attributes
+// and " />".
+func splitStartTag(tag []byte) (head []byte, attrs []textXmlAttr, tail []byte) {
+	isSpace := func(c byte) bool { return c == ' ' || c == '\t' || c == '\r' || c == '\n' }
+	i := 1
+	for i < len(tag) && !isSpace(tag[i]) && tag[i] != '/' && tag[i] != '>' {
+		i++
+	}
+	head = tag[:i]
+	for {
+		j := i
+		for j < len(tag) && isSpace(tag[j]) {
+			j++
+		}
+		if j >= len(tag) || tag[j] == '/' || tag[j] == '>' {
+			return head, attrs, tag[i:]
+		}
+		nameStart := j
+		for j < len(tag) && tag[j] != '=' && !isSpace(tag[j]) {
+			j++
+		}
+		name := tag[nameStart:j]
+		for j < len(tag) && tag[j] != '"' && tag[j] != '\'' {
+			j++
+		}
+		if j >= len(tag) {
+			return head, attrs, tag[i:]
+		}
+		end := bytes.IndexByte(tag[j+1:], tag[j])
+		if end < 0 {
+			return head, attrs, tag[i:]
+		}
+		j += end + 2
+		attrs = append(attrs, textXmlAttr{raw: tag[i:j], name: name})
+		i = j
+	}
+}
+
+// format renders the edited tree. Elements which weren't changed are copied from the source, edited start tags only
+// get their changed attributes rewritten and added elements are formatted like formatTextXml does.
+func (d *textXmlDocument) format(node *XmlNode) []byte {
+	root := node.GetElement()
+	src := d.sources[root]
+	if src == nil {
+		return formatTextXml(node)
+	}
+	var buf bytes.Buffer
+	buf.Write(d.data[:src.start])
+	d.writeElement(&buf, root, nil, src.indent)
+	buf.Write(d.data[src.end:])
+	return buf.Bytes()
+}
+
+// writeElement writes the element without the indentation of its first line.
+func (d *textXmlDocument) writeElement(buf *bytes.Buffer, element *XmlElement, prefixes map[string]string, indent string) {
+	src := d.sources[element]
+	var text string
+	var children []*XmlElement
+	for _, child := range element.Child {
+		if t, ok := child.Node.(*XmlNode_Text); ok {
+			text += t.Text
+		} else {
+			children = append(children, child.GetElement())
+		}
+	}
+	if src == nil || text != src.text {
+		var inner bytes.Buffer
+		writeTextXmlElement(&inner, element, prefixes, indent)
+		buf.WriteString(strings.TrimSuffix(strings.TrimPrefix(inner.String(), indent), "\n"))
+		return
+	}
+
+	scope := map[string]string{}
+	for uri, prefix := range prefixes {
+		scope[uri] = prefix
+	}
+	for _, ns := range element.NamespaceDeclaration {
+		scope[ns.Uri] = ns.Prefix
+	}
+	// New attributes go into their own line if the existing ones do.
+	separator := " "
+	for _, attr := range src.attrs {
+		if space := attr.raw[:len(attr.raw)-len(bytes.TrimLeft(attr.raw, " \t\r\n"))]; bytes.ContainsRune(space, '\n') {
+			separator = string(space)
+		}
+	}
+	buf.Write(src.head)
+	for _, ns := range element.NamespaceDeclaration {
+		if attr := src.namespaceSource(ns); attr != nil && attr.value == ns.Uri {
+			buf.Write(attr.raw)
+		} else if ns.Prefix == "" {
+			buf.WriteString(fmt.Sprintf(`%sxmlns="%s"`, separator, escapeXmlAttribute(ns.Uri)))
+		} else {
+			buf.WriteString(fmt.Sprintf(`%sxmlns:%s="%s"`, separator, ns.Prefix, escapeXmlAttribute(ns.Uri)))
+		}
+	}
+	for _, uri := range missingNamespaces(element, scope) {
+		prefix := namespacePrefix(uri, scope)
+		scope[uri] = prefix
+		buf.WriteString(fmt.Sprintf(`%sxmlns:%s="%s"`, separator, prefix, escapeXmlAttribute(uri)))
+	}
+	for _, attr := range element.Attribute {
+		value := attr.Value
+		if value == "" && attr.CompiledItem != nil {
+			value = formatItem(attr.CompiledItem)
+		}
+		switch existing := src.attributeSource(attr); {
+		case existing != nil && existing.value == value:
+			buf.Write(existing.raw)
+		case existing != nil:
+			// Keep the position of the attribute and only replace its value.
+			nameStart := bytes.Index(existing.raw, existing.name)
+			buf.Write(existing.raw[:nameStart+len(existing.name)])
+			buf.WriteString(fmt.Sprintf(`="%s"`, escapeXmlAttribute(value)))
+		default:
+			buf.WriteString(fmt.Sprintf(`%s%s="%s"`, separator, qualifiedXmlName(attr.NamespaceUri, attr.Name, scope), escapeXmlAttribute(value)))
+		}
+	}
+
+	if src.selfClosing && len(children) == 0 {
+		buf.Write(src.tail)
+		return
+	}
+	childIndent := indent + d.indentUnit
+	if len(src.children) > 0 {
+		childIndent = d.sources[src.children[0]].indent
+	}
+	if src.selfClosing {
+		buf.Write(bytes.TrimRight(bytes.TrimSuffix(src.tail, []byte("/>")), " \t\r\n"))
+		buf.WriteString(">")
+	} else {
+		buf.Write(src.tail)
+	}
+	// Children keep the comments and whitespace in front of them, so removed ones take theirs along.
+	for _, child := range children {
+		if i := src.childIndex(child); i >= 0 {
+			gapStart := src.tagEnd
+			if i > 0 {
+				gapStart = d.sources[src.children[i-1]].end
+			}
+			childSrc := d.sources[child]
+			buf.Write(d.data[gapStart:childSrc.start])
+			d.writeElement(buf, child, scope, childSrc.indent)
+		} else {
+			buf.WriteString("\n" + childIndent)
+			d.writeElement(buf, child, scope, childIndent)
+		}
+	}
+	if src.selfClosing {
+		buf.WriteString("\n" + indent + "</" + string(src.head[1:]) + ">")
+		return
+	}
+	contentEnd := src.tagEnd
+	if len(src.children) > 0 {
+		contentEnd = d.sources[src.children[len(src.children)-1]].end
+	}
+	buf.Write(d.data[contentEnd:src.end])
+}
+
+func (s *textXmlSource) attributeSource(attr *XmlAttribute) *textXmlAttr {
+	for i := range s.attrs {
+		if s.attrs[i].attr == attr {
+			return &s.attrs[i]
+		}
+	}
+	return nil
+}
+
+func (s *textXmlSource) namespaceSource(ns *XmlNamespace) *textXmlAttr {
+	for i := range s.attrs {
+		if s.attrs[i].namespace == ns {
+			return &s.attrs[i]
+		}
+	}
+	return nil
+}
+
+func (s *textXmlSource) childIndex(element *XmlElement) int {
+	for i, child := range s.children {
+		if child == element {
+			return i
+		}
+	}
+	return -1
+}
+
+// formatTextXml renders the XmlNode tree as plain text XML in the style of Android Studio: four spaces of
+// indentation and one attribute per line.
+func formatTextXml(node *XmlNode) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(xmlHeader)
+	writeTextXmlElement(&buf, node.GetElement(), nil, "")
+	return buf.Bytes()
+}
+
+func writeTextXmlElement(buf *bytes.Buffer, element *XmlElement, prefixes map[string]string, indent string) {
+	// Inherit the parent's namespace prefixes and add the ones declared on this element.
+	scope := map[string]string{}
+	for uri, prefix := range prefixes {
+		scope[uri] = prefix
+	}
+	var parts []string
+	for _, ns := range element.NamespaceDeclaration {
+		scope[ns.Uri] = ns.Prefix
+		if ns.Prefix == "" {
+			parts = append(parts, fmt.Sprintf(`xmlns="%s"`, escapeXmlAttribute(ns.Uri)))
+		} else {
+			parts = append(parts, fmt.Sprintf(`xmlns:%s="%s"`, ns.Prefix, escapeXmlAttribute(ns.Uri)))
+		}
+	}
+	// Compiled XML doesn't necessarily contain the namespace declarations, so we add the missing ones.
+	for _, uri := range missingNamespaces(element, scope) {
+		prefix := namespacePrefix(uri, scope)
+		scope[uri] = prefix
+		parts = append(parts, fmt.Sprintf(`xmlns:%s="%s"`, prefix, escapeXmlAttribute(uri)))
+	}
+	name := qualifiedXmlName(element.NamespaceUri, element.Name, scope)
+	for _, attr := range element.Attribute {
+		value := attr.Value
+		if value == "" && attr.CompiledItem != nil {
+			// aapt2 drops the raw value of compiled attributes when converting binary XML.
+			value = formatItem(attr.CompiledItem)
+		}
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, qualifiedXmlName(attr.NamespaceUri, attr.Name, scope), escapeXmlAttribute(value)))
+	}
+
+	buf.WriteString(indent + "<" + name)
+	for i, part := range parts {
+		if i == 0 {
+			buf.WriteString(" " + part)
+		} else {
+			buf.WriteString("\n" + indent + "    " + part)
+		}
+	}
+	if len(element.Child) == 0 {
+		buf.WriteString(" />\n")
+		return
+	}
+
+	hasText := false
+	for _, child := range element.Child {
+		if _, ok := child.Node.(*XmlNode_Text); ok {
+			hasText = true
+		}
+	}
+	if hasText {
+		// Mixed content must be written without any additional whitespace.
+		buf.WriteString(">")
+		for _, child := range element.Child {
+			if text, ok := child.Node.(*XmlNode_Text); ok {
+				xml.EscapeText(buf, []byte(text.Text))
+			} else {
+				var inner bytes.Buffer
+				writeTextXmlElement(&inner, child.GetElement(), scope, "")
+				buf.WriteString(strings.TrimSuffix(inner.String(), "\n"))
+			}
+		}
+		buf.WriteString("</" + name + ">\n")
+		return
+	}
+
+	buf.WriteString(">\n")
+	for i, child := range element.Child {
+		// Separate top-level blocks by an empty line like Android Studio does for the <manifest> children.
+		if indent == "" && i > 0 && len(child.GetElement().GetChild()) > 0 {
+			buf.WriteString("\n")
+		}
+		writeTextXmlElement(buf, child.GetElement(), scope, indent+"    ")
+	}
+	buf.WriteString(indent + "</" + name + ">\n")
+}
+
+func missingNamespaces(element *XmlElement, scope map[string]string) []string {
+	missing := map[string]bool{}
+	if _, ok := scope[element.NamespaceUri]; element.NamespaceUri != "" && !ok {
+		missing[element.NamespaceUri] = true
+	}
+	for _, attr := range element.Attribute {
+		if _, ok := scope[attr.NamespaceUri]; attr.NamespaceUri != "" && !ok {
+			missing[attr.NamespaceUri] = true
+		}
+	}
+	var result []string
+	for uri := range missing {
+		result = append(result, uri)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// namespacePrefix picks a prefix for an undeclared namespace.
+func namespacePrefix(uri string, scope map[string]string) string {
+	if uri == namespace {
+		return "android"
+	}
+	if uri == toolsNamespace {
+		return "tools"
+	}
+	if uri == autoNamespace {
+		return "app"
+	}
+	used := map[string]bool{}
+	for _, prefix := range scope {
+		used[prefix] = true
+	}
+	for i := 0; ; i++ {
+		if prefix := fmt.Sprintf("ns%d", i); !used[prefix] {
+			return prefix
+		}
+	}
+}
+
+func qualifiedXmlName(uri string, name string, scope map[string]string) string {
+	if uri == "" || scope[uri] == "" {
+		return name
+	}
+	return scope[uri] + ":" + name
+}
+
+var xmlAttributeEscaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`"`, "&quot;",
+	"\n", "&#10;",
+	"\r", "&#13;",
+	"\t", "&#9;",
+)
+
+func escapeXmlAttribute(value string) string {
+	return xmlAttributeEscaper.Replace(value)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"testing"
+)
+
+const textManifest = `<?xml version="1.0" encoding="utf-8"?>
+<!-- Library manifest -->
+<manifest xmlns:android="http://schemas.android.com/apk/res/android"
+          package="com.example.lib"
+          android:versionCode="1">
+  <?instruction?>
+  <uses-sdk android:minSdkVersion="21" android:targetSdkVersion='30'/>
+
+  <!-- Camera -->
+  <uses-permission android:name="android.permission.CAMERA" />
+  <application
+      android:label="Lib &amp; more">
+    <activity android:name=".Main"/>
+  </application>
+</manifest>
+`
+
+func TestFormatTextXmlDocument(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(root *XmlElement)
+		want string
+	}{
+		{
+			name: "unchanged",
+			edit: func(root *XmlElement) {},
+			want: textManifest,
+		},
+		{
+			name: "changed attributes",
+			edit: func(root *XmlElement) {
+				getManifestAttribute(&XmlNode{Node: &XmlNode_Element{Element: root}}, namespace, versionCodeAttr).Value = "2"
+				usesSdk := childElements(root, "uses-sdk")[0]
+				usesSdk.Attribute[1].Value = "33"
+				removeAttribute(usesSdk, "minSdkVersion")
+			},
+			want: `<?xml version="1.0" encoding="utf-8"?>
+<!-- Library manifest -->
+<manifest xmlns:android="http://schemas.android.com/apk/res/android"
+          package="com.example.lib"
+          android:versionCode="2">
+  <?instruction?>
+  <uses-sdk android:targetSdkVersion="33"/>
+
+  <!-- Camera -->
+  <uses-permission android:name="android.permission.CAMERA" />
+  <application
+      android:label="Lib &amp; more">
+    <activity android:name=".Main"/>
+  </application>
+</manifest>
+`,
+		},
+		{
+			name: "added and removed elements",
+			edit: func(root *XmlElement) {
+				root.Child = append(root.Child[:1], root.Child[2:]...)
+				application := childElements(root, "application")[0]
+				application.Attribute = append(application.Attribute, &XmlAttribute{NamespaceUri: namespace, Name: "allowBackup", Value: "false"})
+				activity := childElements(application, "activity")[0]
+				activity.Attribute = append(activity.Attribute, &XmlAttribute{NamespaceUri: toolsNamespace, Name: "node", Value: "remove"})
+				addElement(activity, "meta-data", -1)
+				addElement(application, "profileable", -1)
+			},
+			want: `<?xml version="1.0" encoding="utf-8"?>
+<!-- Library manifest -->
+<manifest xmlns:android="http://schemas.android.com/apk/res/android"
+          package="com.example.lib"
+          android:versionCode="1">
+  <?instruction?>
+  <uses-sdk android:minSdkVersion="21" android:targetSdkVersion='30'/>
+  <application
+      android:label="Lib &amp; more"
+      android:allowBackup="false">
+    <activity xmlns:tools="http://schemas.android.com/tools" android:name=".Main" tools:node="remove">
+      <meta-data />
+    </activity>
+    <profileable />
+  </application>
+</manifest>
+`,
+		},
+	}
+	for _, test := range tests {
+		doc, err := parseTextXmlDocument([]byte(textManifest))
+		if err != nil {
+			t.Fatal(err)
+		}
+		test.edit(doc.root.GetElement())
+		if got := string(doc.format(doc.root)); got != test.want {
+			t.Errorf("%s: got\n%s\nwant\n%s", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxVersionCode is the highest versionCode accepted by Google Play.
+const maxVersionCode = 2100000000
+
+// exportedRequiredSdk is the targetSdkVersion from which components with intent filters need android:exported.
+const exportedRequiredSdk = 31
+
+var packageNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*(\.[a-zA-Z][a-zA-Z0-9_]*)+$`)
+
+type severity int
+
+const (
+	severityWarning severity = iota
+	severityError
+)
+
+func (s severity) String() string {
+	if s == severityError {
+		return "error"
+	}
+	return "warning"
+}
+
+// manifestIssue is a problem found by validateManifest or lintManifest. The rule is a short ID like
+// "exported-required" and the path identifies the element like /manifest/application/activity[@name=".MainActivity"].
+type manifestIssue struct {
+	rule     string
+	severity severity
+	path     string
+	message  string
+}
+
+func (i manifestIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s [%s]", i.severity, i.path, i.message, i.rule)
+}
+
+// validateManifest checks the manifest against the rules of the platform and Google Play.
+func validateManifest(manifest *XmlNode) []manifestIssue {
+	var issues []manifestIssue
+	report := func(rule string, severity severity, path string, format string, a ...interface{}) {
+		issues = append(issues, manifestIssue{rule, severity, path, fmt.Sprintf(format, a...)})
+	}
+	root := manifest.GetElement()
+	rootPath := "/" + root.GetName()
+
+	if attr := findAttribute(root, "", "package"); attr != nil && !packageNamePattern.MatchString(attr.GetValue()) {
+		report("package-name", severityError, rootPath, "invalid package name %q", attr.GetValue())
+	}
+	if versionCode, ok := attributeInt(findAttribute(root, namespace, versionCodeAttr)); ok {
+		switch {
+		case versionCode < 0:
+			report("version-code", severityError, rootPath, "versionCode %d is negative", versionCode)
+		case versionCode == 0:
+			report("version-code", severityWarning, rootPath, "versionCode is 0, but Google Play requires a positive versionCode")
+		case versionCode > maxVersionCode:
+			report("version-code", severityError, rootPath, "versionCode %d exceeds Google Play's limit of %d", versionCode, maxVersionCode)
+		}
+	}
+
+	targetSdk, hasTargetSdk := int64(0), false
+	for _, usesSdk := range childElements(root, "uses-sdk") {
+		path := rootPath + "/uses-sdk"
+		minSdk, hasMinSdk := attributeInt(findAttribute(usesSdk, namespace, "minSdkVersion"))
+		targetSdk, hasTargetSdk = attributeInt(findAttribute(usesSdk, namespace, "targetSdkVersion"))
+		maxSdk, hasMaxSdk := attributeInt(findAttribute(usesSdk, namespace, "maxSdkVersion"))
+		if hasMinSdk && hasTargetSdk && minSdk > targetSdk {
+			report("sdk-versions", severityError, path, "minSdkVersion %d is higher than targetSdkVersion %d", minSdk, targetSdk)
+		}
+		if hasMinSdk && hasMaxSdk && minSdk > maxSdk {
+			report("sdk-versions", severityError, path, "minSdkVersion %d is higher than maxSdkVersion %d", minSdk, maxSdk)
+		}
+	}
+
+	for _, tag := range []string{"uses-permission", "uses-permission-sdk-23", "permission"} {
+		seen := map[string]bool{}
+		for _, element := range childElements(root, tag) {
+			name := findAttribute(element, namespace, "name").GetValue()
+			if name == "" {
+				report("permission-name", severityError, rootPath+"/"+tag, "missing android:name")
+			} else if seen[name] {
+				severity := severityWarning
+				if tag == "permission" {
+					severity = severityError
+				}
+				report("duplicate-permission", severity, elementPath(rootPath, element), "duplicate %s", tag)
+			}
+			seen[name] = true
+		}
+	}
+
+	for _, application := range childElements(root, "application") {
+		applicationPath := rootPath + "/application"
+		for _, component := range application.GetChild() {
+			element := component.GetElement()
+			switch element.GetName() {
+			case "activity", "activity-alias", "service", "receiver":
+			default:
+				continue
+			}
+			if len(childElements(element, "intent-filter")) == 0 || findAttribute(element, namespace, "exported") != nil {
+				continue
+			}
+			if hasTargetSdk && targetSdk >= exportedRequiredSdk {
+				report("exported-required", severityError, elementPath(applicationPath, element),
+					"android:exported must be set explicitly for components with intent filters when targeting API %d+", exportedRequiredSdk)
+			}
+		}
+	}
+	return issues
+}
+
+// checkManifest prints the warnings and fails if the manifest has any errors.
+func checkManifest(manifest *XmlNode, config *Config) error {
+	var errors []string
+	for _, issue := range validateManifest(manifest) {
+		if issue.severity == severityError {
+			errors = append(errors, "  "+issue.path+": "+issue.message)
+		} else {
+			config.println("Warning:", issue.path+":", issue.message)
+		}
+	}
+	if len(errors) > 0 {
+		return fmt.Errorf("invalid manifest (use --skip-validation to ignore):\n%s", strings.Join(errors, "\n"))
+	}
+	return nil
+}
+
+// attributeBool returns the boolean value of a compiled or plain text attribute.
+func attributeBool(attr *XmlAttribute) (bool, bool) {
+	if v, ok := attr.GetCompiledItem().GetPrim().GetOneofValue().(*Primitive_BooleanValue); ok {
+		return v.BooleanValue, true
+	}
+	value, err := strconv.ParseBool(strings.TrimSpace(attr.GetValue()))
+	return value, err == nil
+}
+
+// findAttribute returns the element's attribute with the given namespace and name or nil.
+func findAttribute(element *XmlElement, namespaceUri string, name string) *XmlAttribute {
+	for _, attr := range element.GetAttribute() {
+		if attr.GetNamespaceUri() == namespaceUri && attr.GetName() == name {
+			return attr
+		}
+	}
+	return nil
+}
+
+func childElements(element *XmlElement, name string) []*XmlElement {
+	var result []*XmlElement
+	for _, child := range element.GetChild() {
+		if child.GetElement().GetName() == name {
+			result = append(result, child.GetElement())
+		}
+	}
+	return result
+}
+
+// attributeInt returns the integer value of a compiled or plain text attribute.
+func attributeInt(attr *XmlAttribute) (int64, bool) {
+	switch v := attr.GetCompiledItem().GetPrim().GetOneofValue().(type) {
+	case *Primitive_IntDecimalValue:
+		return int64(v.IntDecimalValue), true
+	case *Primitive_IntHexadecimalValue:
+		return int64(v.IntHexadecimalValue), true
+	}
+	if attr == nil {
+		return 0, false
+	}
+	value, err := strconv.ParseInt(strings.TrimSpace(attr.Value), 0, 64)
+	return value, err == nil
+}
+
+func elementPath(parent string, element *XmlElement) string {
+	path := parent + "/" + element.GetName()
+	if name := findAttribute(element, namespace, "name"); name != nil {
+		path += fmt.Sprintf("[@name=%q]", name.GetValue())
+	}
+	return path
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// knownAbis are the ABIs which can be used as versionCode offset keys.
+var knownAbis = []string{"armeabi", "armeabi-v7a", "arm64-v8a", "x86", "x86_64", "mips", "mips64", "riscv64"}
+
+// abiPairs maps 64-bit ABIs to their 32-bit counterparts. Devices which support both get the APK with the higher
+// versionCode, so the 64-bit APK needs the higher one.
+var abiPairs = map[string]string{"arm64-v8a": "armeabi-v7a", "x86_64": "x86", "mips64": "mips"}
+
+// parseVersionCodeOffsets parses a versionCode scheme like arm64-v8a=2000,armeabi-v7a=1000,xxhdpi=30.
+func parseVersionCodeOffsets(list string) (map[string]int32, error) {
+	if list == "" {
+		return nil, nil
+	}
+	offsets := map[string]int32{}
+	for _, item := range splitList(list) {
+		i := strings.IndexByte(item, '=')
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid versionCode offset %q (expected abi=offset or density=offset)", item)
+		}
+		key := item[:i]
+		if _, ok := densities[key]; !ok && !containsString(knownAbis, key) {
+			return nil, fmt.Errorf("invalid versionCode offset %q: %s is no ABI (%s) or density", item, key, strings.Join(knownAbis, ", "))
+		}
+		offset, err := strconv.ParseInt(item[i+1:], 10, 32)
+		if err != nil || offset < 0 {
+			return nil, fmt.Errorf("invalid versionCode offset %q: the offset must be a non-negative number", item)
+		}
+		offsets[key] = int32(offset)
+	}
+	return offsets, nil
+}
+
+// apkTargets returns the ABI and density an APK is made for. They're taken from the split attribute of config
+// splits (e.g. config.arm64_v8a, which bundletool uses) or else the native libraries, if they're for a single ABI.
+func apkTargets(manifest *XmlNode, libs nativeLibs) (abi string, density string) {
+	split := getManifestAttribute(manifest, "", "split").GetValue()
+	if i := strings.LastIndex(split, "config."); i >= 0 {
+		name := split[i+len("config."):]
+		if _, ok := densities[name]; ok {
+			return "", name
+		}
+		for _, known := range knownAbis {
+			if strings.ReplaceAll(known, "-", "_") == name {
+				return known, ""
+			}
+		}
+		return "", ""
+	}
+	if len(libs.abis) == 1 {
+		return libs.abis[0], ""
+	}
+	return "", ""
+}
+
+// versionCode returns the versionCode for the manifest: the configured versionCode plus the offsets of the APK's ABI
+// and density.
+func (e *manifestEditor) versionCode(manifest *XmlNode, config *Config) (int32, error) {
+	if len(config.versionCodeOffsets) == 0 {
+		return config.versionCode, nil
+	}
+	abi, density := apkTargets(manifest, e.nativeLibs)
+	return offsetVersionCode(abi, density, config)
+}
+
+// offsetVersionCode adds the offsets of the ABI and density to the configured versionCode. The result must not
+// exceed Google Play's limit.
+func offsetVersionCode(abi string, density string, config *Config) (int32, error) {
+	versionCode := int64(config.versionCode)
+	var keys []string
+	for _, key := range []string{abi, density} {
+		if offset, ok := config.versionCodeOffsets[key]; ok && key != "" {
+			config.println("Adding versionCode offset", offset, "for", key)
+			versionCode += int64(offset)
+			keys = append(keys, key)
+		}
+	}
+	if versionCode > maxVersionCode {
+		return 0, fmt.Errorf("versionCode %d with the offsets for %s exceeds Google Play's limit of %d", versionCode, strings.Join(keys, " and "), maxVersionCode)
+	}
+	return int32(versionCode), nil
+}
+
+// abiAliases and densityAliases map bundletool's targeting enums to the ABI and density names.
+var abiAliases = map[Abi_AbiAlias]string{
+	Abi_ARMEABI:     "armeabi",
+	Abi_ARMEABI_V7A: "armeabi-v7a",
+	Abi_ARM64_V8A:   "arm64-v8a",
+	Abi_X86:         "x86",
+	Abi_X86_64:      "x86_64",
+	Abi_MIPS:        "mips",
+	Abi_MIPS64:      "mips64",
+	Abi_RISCV64:     "riscv64",
+}
+var densityAliases = map[ScreenDensity_DensityAlias]string{
+	ScreenDensity_NODPI:   "nodpi",
+	ScreenDensity_LDPI:    "ldpi",
+	ScreenDensity_MDPI:    "mdpi",
+	ScreenDensity_TVDPI:   "tvdpi",
+	ScreenDensity_HDPI:    "hdpi",
+	ScreenDensity_XHDPI:   "xhdpi",
+	ScreenDensity_XXHDPI:  "xxhdpi",
+	ScreenDensity_XXXHDPI: "xxxhdpi",
+}
+
+// apkTargeting returns the ABI and density an APK of an APK set is made for.
+func apkTargeting(targeting *ApkTargeting) (abi string, density string) {
+	for _, value := range targeting.GetAbiTargeting().GetValue() {
+		abi = abiAliases[value.GetAlias()]
+	}
+	for _, value := range targeting.GetScreenDensityTargeting().GetValue() {
+		if alias := value.GetDensityAlias(); alias != ScreenDensity_DENSITY_UNSPECIFIED {
+			density = densityAliases[alias]
+		} else {
+			density = densityName(uint32(value.GetDensityDpi()))
+		}
+	}
+	return abi, density
+}
+
+// densityName returns the name of a density like xxhdpi or "" if it has none.
+func densityName(dpi uint32) string {
+	for name, value := range densities {
+		if value == dpi {
+			return name
+		}
+	}
+	return ""
+}
+
+// apkVersionCode is the versionCode of an APK, which is either a file or part of an APK set.
+type apkVersionCode struct {
+	name        string
+	apkSet      string
+	packageName string
+	versionCode int32
+	abi         string
+	density     string
+	split       bool
+}
+
+func (v apkVersionCode) String() string {
+	var targets []string
+	for _, target := range []string{v.abi, v.density} {
+		if target != "" {
+			targets = append(targets, target)
+		}
+	}
+	if len(targets) == 0 {
+		return fmt.Sprintf("%s: versionCode=%d", v.name, v.versionCode)
+	}
+	return fmt.Sprintf("%s: versionCode=%d (%s)", v.name, v.versionCode, strings.Join(targets, ", "))
+}
+
+func readApkVersionCode(name string, a *archive) (apkVersionCode, error) {
+	data, err := a.read("AndroidManifest.xml")
+	if err != nil {
+		return apkVersionCode{}, err
+	}
+	manifest, err := decodeXml(data, detectXmlFormat(data))
+	if err != nil {
+		return apkVersionCode{}, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	v := apkVersionCode{
+		name:        name,
+		packageName: getManifestAttribute(manifest, "", "package").GetValue(),
+		versionCode: getVersionCode(manifest),
+		split:       getManifestAttribute(manifest, "", "split") != nil,
+	}
+	v.abi, v.density = apkTargets(manifest, readNativeLibs(a))
+	return v, nil
+}
+
+// readVersionCodes reads the versionCodes of an APK or of all APKs in an APK set.
+func readVersionCodes(path string, fileType inputType) ([]apkVersionCode, error) {
+	r, size, closeInput, err := openInput(path)
+	if err != nil {
+		return nil, err
+	}
+	defer closeInput()
+	if fileType, err = resolveInputType(r, size, fileType); err != nil {
+		return nil, err
+	}
+	if fileType != inputTypeApk && fileType != inputTypeApks {
+		return nil, fmt.Errorf("versionCodes can only be checked for APKs and APK sets, not %s files", fileType)
+	}
+	a, err := openArchive(r, size)
+	if err != nil {
+		return nil, err
+	}
+	if fileType == inputTypeApk {
+		v, err := readApkVersionCode(path, a)
+		return []apkVersionCode{v}, err
+	}
+
+	toc, err := readApksToc(a)
+	if err != nil {
+		return nil, err
+	}
+	tocApks := readTocApks(toc)
+	var versionCodes []apkVersionCode
+	names := a.names()
+	sort.Strings(names)
+	for _, name := range names {
+		if !strings.HasSuffix(name, ".apk") {
+			continue
+		}
+		data, err := a.read(name)
+		if err != nil {
+			return nil, err
+		}
+		apk, err := openArchive(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		v, err := readApkVersionCode(path+"!"+name, apk)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		v.apkSet = path
+		if tocApk, ok := tocApks[name]; ok {
+			// All split APKs are installed together with their base APK.
+			v.split = !tocApk.standalone
+			if tocApk.abi != "" || tocApk.density != "" {
+				v.abi, v.density = tocApk.abi, tocApk.density
+			}
+		}
+		versionCodes = append(versionCodes, v)
+	}
+	return versionCodes, nil
+}
+
+// versionCodeConflicts reports conflicts which make the installation or upload fail: split APKs with a different
+// versionCode than the rest of their APK set and separate APKs or APK sets of the same app with the same versionCode.
+// The standalone APKs within an APK set are alternatives for different devices, which bundletool gives the same
+// versionCode. Warnings are reported for 64-bit APKs with a lower versionCode than the 32-bit APK's.
+func versionCodeConflicts(versionCodes []apkVersionCode) (conflicts []string, warnings []string) {
+	splits := map[string]apkVersionCode{}
+	var apks []apkVersionCode
+	for _, v := range versionCodes {
+		// Separate APK files and the standalone APKs of APK sets are installed on their own.
+		if v.apkSet == "" || !v.split {
+			apks = append(apks, v)
+			continue
+		}
+		if first, ok := splits[v.apkSet]; !ok {
+			splits[v.apkSet] = v
+		} else if first.versionCode != v.versionCode {
+			conflicts = append(conflicts, fmt.Sprintf("%s has versionCode %d, but %s has %d (split APKs must have the same versionCode)", v.name, v.versionCode, first.name, first.versionCode))
+		}
+	}
+
+	for i, a := range apks {
+		for _, b := range apks[i+1:] {
+			if a.packageName != b.packageName {
+				continue
+			}
+			if a.versionCode == b.versionCode && (a.apkSet == "" || a.apkSet != b.apkSet) {
+				conflicts = append(conflicts, fmt.Sprintf("%s and %s have the same versionCode %d", a.name, b.name, a.versionCode))
+			}
+			for _, pair := range [][2]apkVersionCode{{a, b}, {b, a}} {
+				abi64, abi32 := pair[0], pair[1]
+				if abiPairs[abi64.abi] == abi32.abi && abi32.abi != "" && abi64.versionCode < abi32.versionCode {
+					warnings = append(warnings, fmt.Sprintf("%s has a lower versionCode than %s, so %s devices get the %s APK", abi64.name, abi32.name, abi64.abi, abi32.abi))
+				}
+			}
+		}
+	}
+	return conflicts, warnings
+}
+
+// checkVersionCodes prints the versionCodes of the files and their conflicts and returns the number of conflicts.
+func checkVersionCodes(paths []string, fileType inputType) (int, error) {
+	var versionCodes []apkVersionCode
+	for _, path := range paths {
+		v, err := readVersionCodes(path, fileType)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", path, err)
+		}
+		versionCodes = append(versionCodes, v...)
+	}
+	for _, v := range versionCodes {
+		fmt.Println(v)
+	}
+	conflicts, warnings := versionCodeConflicts(versionCodes)
+	for _, warning := range warnings {
+		fmt.Println("Warning:", warning)
+	}
+	for _, conflict := range conflicts {
+		fmt.Println("Conflict:", conflict)
+	}
+	return len(conflicts), nil
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestVersionCodeConflicts(t *testing.T) {
+	tests := []struct {
+		name         string
+		versionCodes []apkVersionCode
+		conflicts    []string
+		warnings     []string
+	}{
+		{
+			name: "splits with the same versionCode",
+			versionCodes: []apkVersionCode{
+				{name: "a.apks!base-master.apk", apkSet: "a.apks", packageName: "app", versionCode: 1, split: true},
+				{name: "a.apks!base-arm64_v8a.apk", apkSet: "a.apks", packageName: "app", versionCode: 1, abi: "arm64-v8a", split: true},
+			},
+		},
+		{
+			name: "splits with different versionCodes",
+			versionCodes: []apkVersionCode{
+				{name: "a.apks!base-master.apk", apkSet: "a.apks", packageName: "app", versionCode: 1, split: true},
+				{name: "a.apks!base-arm64_v8a.apk", apkSet: "a.apks", packageName: "app", versionCode: 2, abi: "arm64-v8a", split: true},
+			},
+			conflicts: []string{"a.apks!base-arm64_v8a.apk has versionCode 2, but a.apks!base-master.apk has 1"},
+		},
+		{
+			name: "standalones of one APK set with the same versionCode",
+			versionCodes: []apkVersionCode{
+				{name: "a.apks!base-master.apk", apkSet: "a.apks", packageName: "app", versionCode: 1, split: true},
+				{name: "a.apks!standalone-x86.apk", apkSet: "a.apks", packageName: "app", versionCode: 1, abi: "x86"},
+				{name: "a.apks!standalone-x86_64.apk", apkSet: "a.apks", packageName: "app", versionCode: 1, abi: "x86_64"},
+			},
+		},
+		{
+			name: "standalones of different APK sets with the same versionCode",
+			versionCodes: []apkVersionCode{
+				{name: "a.apks!standalone.apk", apkSet: "a.apks", packageName: "app", versionCode: 1},
+				{name: "b.apks!standalone.apk", apkSet: "b.apks", packageName: "app", versionCode: 1},
+			},
+			conflicts: []string{"a.apks!standalone.apk and b.apks!standalone.apk have the same versionCode 1"},
+		},
+		{
+			name: "separate APKs with the same versionCode",
+			versionCodes: []apkVersionCode{
+				{name: "arm.apk", packageName: "app", versionCode: 1, abi: "armeabi-v7a"},
+				{name: "arm64.apk", packageName: "app", versionCode: 1, abi: "arm64-v8a"},
+				{name: "other.apk", packageName: "other", versionCode: 1},
+			},
+			conflicts: []string{"arm.apk and arm64.apk have the same versionCode 1"},
+		},
+		{
+			name: "64-bit APK with a lower versionCode",
+			versionCodes: []apkVersionCode{
+				{name: "arm64.apk", packageName: "app", versionCode: 1, abi: "arm64-v8a"},
+				{name: "arm.apk", packageName: "app", versionCode: 2, abi: "armeabi-v7a"},
+			},
+			warnings: []string{"arm64.apk has a lower versionCode than arm.apk"},
+		},
+	}
+	for _, test := range tests {
+		conflicts, warnings := versionCodeConflicts(test.versionCodes)
+		checkMessages(t, test.name+" conflicts", conflicts, test.conflicts)
+		checkMessages(t, test.name+" warnings", warnings, test.warnings)
+	}
+}
+
+// checkMessages checks that each message starts with the wanted prefix.
+func checkMessages(t *testing.T, name string, messages []string, prefixes []string) {
+	t.Helper()
+	if len(messages) != len(prefixes) {
+		t.Errorf("%s: got %q, want %q", name, messages, prefixes)
+		return
+	}
+	for i, message := range messages {
+		if !strings.HasPrefix(message, prefixes[i]) {
+			t.Errorf("%s: got %q, want %q", name, message, prefixes[i])
+		}
+	}
+}
+
+func TestApkTargeting(t *testing.T) {
+	densityTargeting := func(density *ScreenDensity) *ScreenDensityTargeting {
+		return &ScreenDensityTargeting{Value: []*ScreenDensity{density}}
+	}
+	tests := []struct {
+		targeting *ApkTargeting
+		abi       string
+		density   string
+	}{
+		{nil, "", ""},
+		{&ApkTargeting{AbiTargeting: &AbiTargeting{Value: []*Abi{{Alias: Abi_ARM64_V8A}}}}, "arm64-v8a", ""},
+		{&ApkTargeting{AbiTargeting: &AbiTargeting{Alternatives: []*Abi{{Alias: Abi_X86}}}}, "", ""},
+		{&ApkTargeting{ScreenDensityTargeting: densityTargeting(&ScreenDensity{DensityOneof: &ScreenDensity_DensityAlias_{DensityAlias: ScreenDensity_XXHDPI}})}, "", "xxhdpi"},
+		{&ApkTargeting{ScreenDensityTargeting: densityTargeting(&ScreenDensity{DensityOneof: &ScreenDensity_DensityDpi{DensityDpi: 240}})}, "", "hdpi"},
+		{&ApkTargeting{ScreenDensityTargeting: densityTargeting(&ScreenDensity{DensityOneof: &ScreenDensity_DensityDpi{DensityDpi: 420}})}, "", ""},
+		{&ApkTargeting{
+			AbiTargeting:           &AbiTargeting{Value: []*Abi{{Alias: Abi_X86}}},
+			ScreenDensityTargeting: densityTargeting(&ScreenDensity{DensityOneof: &ScreenDensity_DensityAlias_{DensityAlias: ScreenDensity_MDPI}}),
+		}, "x86", "mdpi"},
+	}
+	for _, test := range tests {
+		abi, density := apkTargeting(test.targeting)
+		if !reflect.DeepEqual([]string{abi, density}, []string{test.abi, test.density}) {
+			t.Errorf("apkTargeting(%v) = %s, %s, want %s, %s", test.targeting, abi, density, test.abi, test.density)
+		}
+	}
+}
+
+func TestOffsetVersionCode(t *testing.T) {
+	config := &Config{versionCode: 100, versionCodeOffsets: map[string]int32{"arm64-v8a": 2000, "xxhdpi": 30}, out: ioutil.Discard}
+	tests := []struct {
+		abi         string
+		density     string
+		versionCode int32
+	}{
+		{"", "", 100},
+		{"arm64-v8a", "", 2100},
+		{"arm64-v8a", "xxhdpi", 2130},
+		{"x86", "hdpi", 100},
+	}
+	for _, test := range tests {
+		if versionCode, err := offsetVersionCode(test.abi, test.density, config); err != nil || versionCode != test.versionCode {
+			t.Errorf("offsetVersionCode(%q, %q) = %d, %v, want %d", test.abi, test.density, versionCode, err, test.versionCode)
+		}
+	}
+
+	config.versionCode = maxVersionCode - 10
+	if _, err := offsetVersionCode("arm64-v8a", "", config); err == nil {
+		t.Error("offsetVersionCode() above the limit succeeded, want an error")
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// xmlFormat is the serialization format of an XML file like the AndroidManifest.xml.
+type xmlFormat int
+
+const (
+	// Compiled XmlNode protobuf, as used in AABs and aapt2's proto APKs.
+	xmlFormatProto xmlFormat = iota
+	// Android's binary XML (AXML), as used in APKs.
+	xmlFormatBinary
+	// Plain text XML, as used in AARs and Gradle intermediates.
+	xmlFormatText
+)
+
+func (f xmlFormat) String() string {
+	switch f {
+	case xmlFormatBinary:
+		return "binary XML"
+	case xmlFormatText:
+		return "text XML"
+	}
+	return "proto XML"
+}
+
+// detectXmlFormat detects the format by looking at the first bytes of the file.
+func detectXmlFormat(data []byte) xmlFormat {
+	if isBinaryXml(data) {
+		return xmlFormatBinary
+	}
+	text := bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if text = bytes.TrimLeft(text, " \t\r\n"); len(text) > 0 && text[0] == '<' {
+		return xmlFormatText
+	}
+	return xmlFormatProto
+}
+
+func decodeXml(data []byte, format xmlFormat) (*XmlNode, error) {
+	switch format {
+	case xmlFormatBinary:
+		return parseBinaryXml(data)
+	case xmlFormatText:
+		return parseTextXml(data)
+	}
+	xmlNode := &XmlNode{}
+	if err := proto.Unmarshal(data, xmlNode); err != nil {
+		return nil, err
+	}
+	if xmlNode.GetElement() == nil {
+		return nil, fmt.Errorf("missing root element")
+	}
+	return xmlNode, nil
+}
+
+func encodeXml(xmlNode *XmlNode, format xmlFormat) ([]byte, error) {
+	switch format {
+	case xmlFormatBinary:
+		return formatBinaryXml(xmlNode)
+	case xmlFormatText:
+		return formatTextXml(xmlNode), nil
+	}
+	// We use MarshalVT because it keeps the correct field ordering.
+	// With the standard Marshal function, Android Studio can't read the resulting proto file inside aab files. :-/
+	return xmlNode.MarshalVT()
+}
+
+var dimensionUnits = []string{"px", "dp", "sp", "pt", "in", "mm"}
+
+// formatItem renders a compiled item as it would appear in a text XML file.
+func formatItem(item *Item) string {
+	switch v := item.GetValue().(type) {
+	case *Item_Ref:
+		prefix := "@"
+		if v.Ref.GetType() == Reference_ATTRIBUTE {
+			prefix = "?"
+		}
+		if v.Ref.GetName() != "" {
+			return prefix + v.Ref.GetName()
+		}
+		if v.Ref.GetId() == 0 {
+			return "@null"
+		}
+		return fmt.Sprintf("%s0x%08x", prefix, v.Ref.GetId())
+	case *Item_Str:
+		return v.Str.GetValue()
+	case *Item_RawStr:
+		return v.RawStr.GetValue()
+	case *Item_StyledStr:
+		return v.StyledStr.GetValue()
+	case *Item_File:
+		return v.File.GetPath()
+	case *Item_Prim:
+		switch p := v.Prim.GetOneofValue().(type) {
+		case *Primitive_NullValue:
+			return "@null"
+		case *Primitive_EmptyValue:
+			return "@empty"
+		case *Primitive_FloatValue:
+			return fmt.Sprint(p.FloatValue)
+		case *Primitive_DimensionValue:
+			return formatComplex(p.DimensionValue, dimensionUnits)
+		case *Primitive_FractionValue:
+			return formatComplex(p.FractionValue, []string{"%", "%p"})
+		case *Primitive_IntDecimalValue:
+			return fmt.Sprint(p.IntDecimalValue)
+		case *Primitive_IntHexadecimalValue:
+			return fmt.Sprintf("0x%x", p.IntHexadecimalValue)
+		case *Primitive_BooleanValue:
+			return fmt.Sprint(p.BooleanValue)
+		case *Primitive_ColorArgb8Value:
+			return fmt.Sprintf("#%08x", p.ColorArgb8Value)
+		case *Primitive_ColorRgb8Value:
+			return fmt.Sprintf("#%06x", p.ColorRgb8Value&0xffffff)
+		case *Primitive_ColorArgb4Value:
+			c := p.ColorArgb4Value
+			return fmt.Sprintf("#%x%x%x%x", c>>28&0xf, c>>20&0xf, c>>12&0xf, c>>4&0xf)
+		case *Primitive_ColorRgb4Value:
+			c := p.ColorRgb4Value
+			return fmt.Sprintf("#%x%x%x", c>>20&0xf, c>>12&0xf, c>>4&0xf)
+		}
+	}
+	return ""
+}
+
+// formatComplex decodes a dimension or fraction in the Res_value complex format.
+func formatComplex(value uint32, units []string) string {
+	mantissa := float64(int32(value&0xffffff00) >> 8)
+	radixShift := []uint{0, 7, 15, 23}[value>>4&0x3]
+	number := mantissa / float64(uint32(1)<<radixShift)
+	unit := ""
+	if i := int(value & 0xf); i < len(units) {
+		unit = units[i]
+	}
+	if unit == "%" || unit == "%p" {
+		number *= 100
+	}
+	return fmt.Sprintf("%g%s", number, unit)
+}